@@ -1,33 +1,14 @@
 package main
 
 import (
-    "bufio"
     "fmt"
     "image"
     "image/color"
     "image/png"
-    "io/ioutil"
     "math"
     "os"
-    "strconv"
-    "strings"
-    "sync"
-    "time"
-)
-
-type instructionOperation int
 
-const (
-    Add             instructionOperation = 1
-    Multiply        instructionOperation = 2
-    Read            instructionOperation = 3
-    Write           instructionOperation = 4
-    JumpIfTrue      instructionOperation = 5
-    JumpIfFalse     instructionOperation = 6
-    LessThan        instructionOperation = 7
-    Equals          instructionOperation = 8
-    SetRelativeBase instructionOperation = 9
-    Terminate       instructionOperation = 99
+    "github.com/tomasbobek/AdventOfCode19/intcode"
 )
 
 type direction int
@@ -39,12 +20,6 @@ const (
     left
 )
 
-var (
-    InstructionLength = map[instructionOperation]int{
-        Add:4, Multiply:4, Read:2, Write:2, JumpIfTrue:3, JumpIfFalse:3, LessThan:4, Equals:4, SetRelativeBase:2, Terminate:1,
-    }
-)
-
 func main() {
     path, err := os.Getwd()
     if err != nil {
@@ -53,8 +28,21 @@ func main() {
 
     // -----------------------------------------------------------------------------------------------------------------
     // Here we solve problem for Part One
-    robot := newPaintingRobotWithProgram(path + "/11/code")
-    robot.run()
+    robot, err := newPaintingRobotWithProgram(path + "/11/code")
+    if err != nil {
+        fmt.Println(err)
+        return
+    }
+
+    err = robot.run()
+    if err != nil {
+        fmt.Println("robot brain failed:", err)
+
+        // Failed run can be reproduced with: go run ./intcode/cmd/replay 11/session.jsonl 11/code
+        if err := robot.brain.Session.SaveToFile(path + "/11/session.jsonl"); err != nil {
+            fmt.Println(err)
+        }
+    }
 
     fmt.Println("robot painted ", len(robot.paintedPoints), " tiles on the ship hull")
 
@@ -63,74 +51,84 @@ func main() {
     robot.exportToImage(path + "/11/registration.png")
 }
 
-func newPaintingRobotWithProgram(programPath string) *paintingRobot {
-    inChannel := make(chan int64)
-    outChannel := make(chan int64)
-    doneChannel := make(chan interface{})
-
-    program := &program{
-        inChannel: inChannel,
-        outChannel: outChannel,
-        done: doneChannel,
+func newPaintingRobotWithProgram(programPath string) (*paintingRobot, error) {
+    // Inputs and outputs of the brain are recorded so its failure can be replayed
+    program := &intcode.Program{Session: &intcode.Session{}}
+    err := program.LoadCodeFromFile(programPath)
+    if err != nil {
+        return nil, err
     }
-    program.loadCodeFromFile(programPath)
 
-    return &paintingRobot{
+    robot := &paintingRobot{
         brain: program,
         direction: up,
         position:  &point{
             x:     0,
             y:     0,
         },
+        startColor: 1,
+        readingColor: true,
     }
+
+    return robot, nil
 }
 
 type paintingRobot struct {
-    brain         *program
+    brain         *intcode.Program
     position      *point
     direction     direction
     paintedPoints []*point
-}
 
-func (r *paintingRobot) run() {
-    wg := sync.WaitGroup{}
-    wg.Add(1)
-    go r.brain.execute()
-    go func() {
-        r.brain.inChannel <- 1
-        readingColor := true
-
-        robotLoop: for {
-            var scannedColor int
-            select {
-            case reading := <-r.brain.outChannel:
-                // Program outputs have 2 possible meanings that switch periodically:
-                //  * color (0 - black, 1 - white)
-                //  * rotation (0 - CCW, 1 - CW)
-                if readingColor {
-                    r.paint(int(reading))
-                } else {
-                    r.changeDirection(int(reading))
-                    r.move()
-                    scannedColor = r.scanColor()
-
-                    // After orientation change the program expects the code of detected color on that position as input.
-                    select {
-                    case r.brain.inChannel <- int64(scannedColor):
-                        fmt.Println("robot detected color ", scannedColor)
-                    case <-r.brain.done:
-                    }
-                }
-
-                readingColor = !readingColor
-
-            case <-r.brain.done:
-                wg.Done()
-                break robotLoop
-            }
+    // Color of the tile robot starts on, it is the first input of the program
+    startColor    int
+    started       bool
+    // Program outputs alternate between color and rotation
+    readingColor  bool
+}
+
+// Runs the robot until its brain program completes, the returned error tells why the brain has stopped
+// in case it was not a regular end of the program. Robot drives its brain step by step, it answers every
+// request for input and handles every output as soon as the program produces it.
+func (r *paintingRobot) run() error {
+    for {
+        switch result := r.brain.Run(); result.Status {
+        case intcode.NeedsInput:
+            r.brain.PushInput(r.scanInput())
+        case intcode.ProducedOutput:
+            r.processOutput(result.Output)
+        case intcode.Halted:
+            return nil
+        case intcode.Faulted:
+            return result.Err
         }
-    }()
-    wg.Wait()
+    }
+}
+
+// After orientation change the program expects the code of detected color on that position as input.
+func (r *paintingRobot) scanInput() int64 {
+    if !r.started {
+        r.started = true
+        return int64(r.startColor)
+    }
+
+    scannedColor := r.scanColor()
+    fmt.Println("robot detected color ", scannedColor)
+
+    return int64(scannedColor)
+}
+
+// Program outputs have 2 possible meanings that switch periodically:
+//  * color (0 - black, 1 - white)
+//  * rotation (0 - CCW, 1 - CW)
+func (r *paintingRobot) processOutput(reading int64) {
+    if r.readingColor {
+        r.paint(int(reading))
+    } else {
+        r.changeDirection(int(reading))
+        r.move()
+    }
+
+    r.readingColor = !r.readingColor
 }
 
 // Gives the tile a color based on input (0 - black, 1 - white).
@@ -269,277 +267,3 @@ type point struct {
     y     int
     color int
 }
-
-type instruction struct {
-    operation instructionOperation
-    length int
-    params []instructionParam
-}
-
-func (i *instruction) initialize(intCode []int64, pIndex int) {
-    instValue := int(intCode[pIndex])
-
-    i.operation = instructionOperation(instValue)
-
-    // Standard operation Codes are between 1 and 99, larger number means that Parameter Modes are included there
-    evalParamModes := false
-    if instValue >= 100 {
-        i.operation = instructionOperation(instValue % 100)
-        evalParamModes = true
-    }
-
-    i.length = InstructionLength[i.operation]
-    paramCount := i.length - 1
-    i.params = make([]instructionParam, paramCount, paramCount)
-
-    for j := 0; j < paramCount; j++ {
-        i.params[j] = instructionParam{0, intCode[pIndex+j+1]}
-
-        // Parameter mode is either 0 (by reference) or 1 (by value) and this mode is specified
-        // in the instruction code itself (as given number at respective position)
-        if evalParamModes {
-            i.params[j].mode = (instValue / int(math.Pow(float64(10), float64(j+2)))) % 10
-        }
-    }
-}
-
-func (i *instruction) getValuesCount() int {
-    switch i.operation {
-    case Add, Multiply, JumpIfTrue, JumpIfFalse, LessThan, Equals:
-        return 2
-    case Write, SetRelativeBase:
-        return 1
-    default:
-        return 0
-    }
-}
-
-func (i *instruction) doesStoreOutputInMemory() bool {
-    return i.operation == Read || i.operation == Add || i.operation == Multiply || i.operation == LessThan || i.operation == Equals
-}
-
-type instructionParam struct {
-    mode  int
-    value int64
-}
-
-type program struct {
-    memory       []int64
-    memorySize   int
-    position     int
-    relativeBase int
-    completed    bool
-    halt         bool
-
-    inChannel    chan int64
-    outChannel   chan int64
-    done         chan interface{}
-
-    dataStack    []int64
-    haltOnOutput bool
-}
-
-func (p *program) loadCodeFromFile(file string) {
-    bytes, err := ioutil.ReadFile(file)
-
-    if err != nil {
-        fmt.Println(err)
-    }
-
-    inputs := strings.Split(string(bytes), ",")
-    intInputs, err := convertStringArray(inputs)
-
-    if err != nil {
-        fmt.Println(err)
-    }
-
-    p.memorySize = len(intInputs) * 10
-    p.memory = make([]int64, p.memorySize, p.memorySize)
-    for i := 0; i < len(intInputs); i++ {
-        p.memory[i] = intInputs[i]
-    }
-}
-
-func (p *program) resetState() {
-    p.position = 0
-    p.completed = false
-    p.halt = false
-}
-
-func (p *program) resetMemory() {
-    p.dataStack = make([]int64, p.memorySize, p.memorySize)
-}
-
-func (p *program) execute() {
-    for !p.completed && !p.halt {
-        var instruction instruction
-        instruction.initialize(p.memory, p.position)
-
-        p.loadParameterValues(&instruction)
-
-        switch instruction.operation {
-        case Add:
-            p.doAdd(&instruction)
-        case Multiply:
-            p.doMultiply(&instruction)
-        case Read:
-            p.doReadInput(&instruction)
-        case Write:
-            p.doWriteOutput(&instruction)
-        case JumpIfTrue:
-            p.doJumpIfTrue(&instruction)
-        case JumpIfFalse:
-            p.doJumpIfFalse(&instruction)
-        case LessThan:
-            p.doComparisonLessThan(&instruction)
-        case Equals:
-            p.doComparisonEquals(&instruction)
-        case SetRelativeBase:
-            p.doUpdateRelativeBase(&instruction)
-        case Terminate:
-            fmt.Println("program finished")
-            p.completed = true
-            close(p.done)
-            close(p.outChannel)
-        default:
-            fmt.Println("Encountered invalid OpCode: ", instruction.operation)
-            p.completed = true
-            close(p.done)
-            close(p.outChannel)
-        }
-    }
-}
-
-// Parameters can be handled "by value" or "by reference" and this function supplies the end value in each case
-func (p *program) loadParameterValues(i *instruction) {
-    for j := 0; j < i.getValuesCount(); j++ {
-        switch i.params[j].mode {
-        case 0:
-            i.params[j].value = p.memory[i.params[j].value]
-        case 2:
-            i.params[j].value = p.memory[p.relativeBase+ int(i.params[j].value)]
-        }
-    }
-
-    if i.doesStoreOutputInMemory() {
-        if i.params[i.getValuesCount()].mode == 2 {
-            i.params[i.getValuesCount()].value = int64(p.relativeBase) + i.params[i.getValuesCount()].value
-        }
-    }
-}
-
-func (p *program) doAdd(i *instruction) {
-    p.memory[i.params[2].value] = i.params[0].value + i.params[1].value
-    p.position += i.length
-}
-
-func (p *program) doMultiply(i *instruction) {
-    p.memory[i.params[2].value] = i.params[0].value * i.params[1].value
-    p.position += i.length
-}
-
-// Inputs are primarily read from dataStack of the program, if it is empty, input is prompted from Standard Input
-func (p *program) doReadInput(i *instruction) {
-    var input int64
-    channelReadOk := false
-
-    if p.inChannel != nil {
-        select {
-        case <-time.After(10 * time.Second):
-            fmt.Println("waiting for input timed-out, trying to read from dataStack")
-        case input = <-p.inChannel:
-            channelReadOk = true
-        }
-    }
-
-    if !channelReadOk {
-        if len(p.dataStack) > 0 {
-            input = p.dataStack[len(p.dataStack)-1]
-            p.dataStack = p.dataStack[:len(p.dataStack)-1]
-        } else {
-            reader := bufio.NewReader(os.Stdin)
-            fmt.Print("Enter value: ")
-            value, err := reader.ReadString('\n')
-
-            if err != nil {
-                fmt.Println(err)
-            }
-
-            inputInt, err := strconv.Atoi(strings.TrimSuffix(value, "\n"))
-
-            if err != nil {
-                fmt.Println(err)
-            }
-
-            input = int64(inputInt)
-        }
-    }
-
-    p.memory[i.params[0].value] = input
-    p.position += i.length
-}
-
-// program outputs are logged to Standard Output and stored in internal Data Stack
-func (p *program) doWriteOutput(i *instruction) {
-    if p.outChannel != nil {
-        p.outChannel <- i.params[0].value
-    } else {
-        p.dataStack = append(p.dataStack, i.params[0].value)
-    }
-    p.position += i.length
-
-    if p.haltOnOutput {
-        p.halt = true
-    }
-}
-
-func (p *program) doJumpIfTrue(i *instruction) {
-    if i.params[0].value != 0 {
-        p.position = int(i.params[1].value)
-    } else {
-        p.position += i.length
-    }
-}
-
-func (p *program) doJumpIfFalse(i *instruction) {
-    if i.params[0].value == 0 {
-        p.position = int(i.params[1].value)
-    } else {
-        p.position += i.length
-    }
-}
-
-func (p *program) doComparisonLessThan(i *instruction) {
-    if i.params[0].value < i.params[1].value {
-        p.memory[i.params[2].value] = 1
-    } else {
-        p.memory[i.params[2].value] = 0
-    }
-    p.position += i.length
-}
-
-func (p *program) doComparisonEquals(i *instruction) {
-    if i.params[0].value == i.params[1].value {
-        p.memory[i.params[2].value] = 1
-    } else {
-        p.memory[i.params[2].value] = 0
-    }
-    p.position += i.length
-}
-
-func (p *program) doUpdateRelativeBase(i *instruction) {
-    p.relativeBase += int(i.params[0].value)
-    p.position += i.length
-}
-
-func convertStringArray(strArr []string) ([]int64, error) {
-    iArr := make([]int64, 0, len(strArr))
-    for _, str := range strArr {
-        i, err := strconv.Atoi(str)
-        if err != nil {
-            return nil, err
-        }
-        iArr = append(iArr, int64(i))
-    }
-    return iArr, nil
-}
@@ -2,91 +2,47 @@ package main
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
-	"strconv"
-	"strings"
+
+	"github.com/tomasbobek/AdventOfCode19/intcode"
 )
 
 func main() {
-main:
-	for n := 0; n < 100; n++ {
-		for v := 0; v < 100; v++ {
-			sequence := loadSequence()
-			returnCode := executeProgram(sequence, n, v)
-
-			if returnCode == 19690720 {
-				fmt.Println(fmt.Sprintf("Noun: %d, Verb: %d, Code: %d", n, v, 100*n+v))
-				break main
-			}
-		}
-	}
-}
-
-func loadSequence() []int {
 	path, err := os.Getwd()
 	if err != nil {
 		fmt.Println(err)
 	}
 
-	bytes, err := ioutil.ReadFile(path + "/2/code")
-
-	if err != nil {
+	program := intcode.Program{Quiet: true}
+	if err := program.LoadCodeFromFile(path + "/2/code"); err != nil {
 		fmt.Println(err)
+		return
 	}
+	initialState := program.Snapshot()
 
-	inputs := strings.Split(string(bytes), ",")
-	intInputs, err := convertStringArray(inputs)
-
-	if err != nil {
-		fmt.Println(err)
-	}
-
-	return intInputs
-}
-
-func executeProgram(sequence []int, noun, verb int) int {
-	run := true
-	seqPos := 0
-
-	sequence[1] = noun
-	sequence[2] = verb
+main:
+	for n := 0; n < 100; n++ {
+		for v := 0; v < 100; v++ {
+			program.Restore(initialState)
+			returnCode, err := executeProgram(&program, n, v)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
 
-	for run {
-		switch sequence[seqPos] {
-		case 1:
-			add(sequence, seqPos)
-		case 2:
-			multiply(sequence, seqPos)
-		case 99:
-			run = false
-		default:
-			fmt.Println("Encountered invalid OpCode: ", sequence[seqPos])
-			run = false
+			if returnCode == 19690720 {
+				fmt.Println(fmt.Sprintf("Noun: %d, Verb: %d, Code: %d", n, v, 100*n+v))
+				break main
+			}
 		}
-
-		seqPos += 4
 	}
-
-	return sequence[0]
 }
 
-func add(a []int, pos int) {
-	a[a[pos+3]] = a[a[pos+1]] + a[a[pos+2]]
-}
+func executeProgram(program *intcode.Program, noun, verb int) (int64, error) {
+	program.Memory[1] = int64(noun)
+	program.Memory[2] = int64(verb)
 
-func multiply(a []int, pos int) {
-	a[a[pos+3]] = a[a[pos+1]] * a[a[pos+2]]
-}
+	err := program.Execute()
 
-func convertStringArray(strArr []string) ([]int, error) {
-	iArr := make([]int, 0, len(strArr))
-	for _, str := range strArr {
-		i, err := strconv.Atoi(str)
-		if err != nil {
-			return nil, err
-		}
-		iArr = append(iArr, i)
-	}
-	return iArr, nil
+	return program.Memory[0], err
 }
@@ -0,0 +1,3 @@
+module github.com/tomasbobek/AdventOfCode19
+
+go 1.13
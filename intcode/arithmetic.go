@@ -0,0 +1,194 @@
+package intcode
+
+import (
+    "context"
+    "fmt"
+    "math"
+    "math/big"
+)
+
+// ArithmeticMode tells how the results of Add and Multiply instructions that do not fit into int64 are handled
+type ArithmeticMode int
+
+const (
+    // Results wrap around like the int64 arithmetic of Go
+    WrappingArithmetic ArithmeticMode = iota
+    // Results that do not fit into int64 stop the program with OverflowError
+    CheckedArithmetic
+    // Results are exact, values that do not fit into int64 are kept as big.Int in a separate memory (ReadMemory
+    // returns 0 for them, see ReadBigMemory). They can be added, multiplied, compared, tested by jumps and written
+    // to Output implementing BigOutput, using them as an address or relative base offset fails with OverflowError.
+    BigArithmetic
+)
+
+// ParseArithmetic reads the arithmetic mode by its name: wrap, checked or big
+func ParseArithmetic(s string) (ArithmeticMode, error) {
+    switch s {
+    case "wrap":
+        return WrappingArithmetic, nil
+    case "checked":
+        return CheckedArithmetic, nil
+    case "big":
+        return BigArithmetic, nil
+    }
+    return 0, fmt.Errorf("unknown arithmetic %q, use wrap, checked or big", s)
+}
+
+// BigOutput receives output values that do not fit into int64 in BigArithmetic mode
+type BigOutput interface {
+    WriteBigOutput(ctx context.Context, value *big.Int) error
+}
+
+// ReadBigMemory returns the value on given address including values that do not fit into int64
+func (p *Program) ReadBigMemory(address int64) (*big.Int, error) {
+    if value, ok := p.bigMemory[address]; ok {
+        return new(big.Int).Set(value), nil
+    }
+
+    value, err := p.ReadMemory(address)
+    if err != nil {
+        return nil, err
+    }
+    return big.NewInt(value), nil
+}
+
+// Computes the result of Add or Multiply instruction in CheckedArithmetic or BigArithmetic mode
+func (p *Program) calculate(i *Instruction) {
+    a, b := i.Params[0].Value, i.Params[1].Value
+
+    var result int64
+    var overflow bool
+    if i.Operation == Add {
+        result, overflow = addInt64(a, b)
+    } else {
+        result, overflow = multiplyInt64(a, b)
+    }
+
+    switch {
+    case !overflow:
+        p.store(i.Params[2].Value, result)
+    case p.Arithmetic == CheckedArithmetic:
+        p.fail(&OverflowError{Address: p.Position, Operation: i.Operation, A: a, B: b})
+    default:
+        p.storeBig(i.Params[2].Value, p.calculateBig(i))
+    }
+}
+
+func (p *Program) calculateBig(i *Instruction) *big.Int {
+    if i.Operation == Add {
+        return new(big.Int).Add(p.bigOperand(i, 0), p.bigOperand(i, 1))
+    }
+    return new(big.Int).Mul(p.bigOperand(i, 0), p.bigOperand(i, 1))
+}
+
+func addInt64(a int64, b int64) (int64, bool) {
+    result := a + b
+    // Overflow happens only when both operands have the same sign, which differs from the sign of the result
+    return result, (a ^ result) & (b ^ result) < 0
+}
+
+func multiplyInt64(a int64, b int64) (int64, bool) {
+    if a == 0 || b == 0 {
+        return 0, false
+    }
+
+    result := a * b
+    overflow := result / b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64)
+    return result, overflow
+}
+
+// Executes the instruction that has some operands not fitting into int64, they were loaded by loadParameterValues
+func (p *Program) executeBig(i *Instruction) {
+    switch i.Operation {
+    case Add, Multiply:
+        p.storeBig(i.Params[2].Value, p.calculateBig(i))
+    case LessThan:
+        p.store(i.Params[2].Value, boolValue(p.bigOperand(i, 0).Cmp(p.bigOperand(i, 1)) < 0))
+    case Equals:
+        p.store(i.Params[2].Value, boolValue(p.bigOperand(i, 0).Cmp(p.bigOperand(i, 1)) == 0))
+    case JumpIfTrue, JumpIfFalse:
+        // Big values are never zero, so only the jump target can be the big one when the jump is not taken
+        jump := (p.bigOperand(i, 0).Sign() != 0) == (i.Operation == JumpIfTrue)
+        if jump {
+            if p.bigOperands[1] != nil {
+                p.fail(&OverflowError{Address: p.Position, Operation: i.Operation})
+                return
+            }
+            p.Position = int(i.Params[1].Value)
+            return
+        }
+    case Write:
+        output, ok := p.output().(BigOutput)
+        if !ok {
+            p.fail(&OverflowError{Address: p.Position, Operation: i.Operation})
+            return
+        }
+        value := p.bigOperand(i, 0)
+        if p.traceRecord != nil {
+            p.traceRecord.BigOutput = value
+        }
+        if err := output.WriteBigOutput(p.context(), value); err != nil {
+            p.fail(fmt.Errorf("writing output at address %d: %w", p.Position, err))
+            return
+        }
+        if p.Journal != nil {
+            p.Journal.recordOutput()
+        }
+        if p.Session != nil {
+            p.Session.recordBigOutput(p.Steps, value)
+        }
+    default:
+        p.fail(&OverflowError{Address: p.Position, Operation: i.Operation})
+        return
+    }
+
+    p.Position += i.Length
+}
+
+// Reports whether any of the loaded operands of the instruction does not fit into int64
+func (p *Program) hasBigOperands(i *Instruction) bool {
+    for j := 0; j < i.getValuesCount(); j++ {
+        if p.bigOperands[j] != nil {
+            return true
+        }
+    }
+    return false
+}
+
+// Returns value of the loaded operand as big.Int, it must not be modified
+func (p *Program) bigOperand(i *Instruction, j int) *big.Int {
+    if p.bigOperands[j] != nil {
+        return p.bigOperands[j]
+    }
+    return big.NewInt(i.Params[j].Value)
+}
+
+// Stores the value that might not fit into int64
+func (p *Program) storeBig(address int64, value *big.Int) {
+    if value.IsInt64() {
+        p.store(address, value.Int64())
+        return
+    }
+
+    p.store(address, 0)
+    if p.fault != nil {
+        return
+    }
+
+    if p.bigMemory == nil {
+        p.bigMemory = map[int64]*big.Int{}
+    }
+    p.bigMemory[address] = value
+}
+
+func copyBigMemory(memory map[int64]*big.Int) map[int64]*big.Int {
+    if len(memory) == 0 {
+        return nil
+    }
+
+    copied := make(map[int64]*big.Int, len(memory))
+    for address, value := range memory {
+        copied[address] = new(big.Int).Set(value)
+    }
+    return copied
+}
@@ -0,0 +1,179 @@
+package intcode
+
+import (
+    "bytes"
+    "errors"
+    "math"
+    "testing"
+)
+
+func TestInt64Overflow(t *testing.T) {
+    tests := []struct {
+        name      string
+        operation func(a int64, b int64) (int64, bool)
+        a, b      int64
+        result    int64
+        overflow  bool
+    }{
+        {"add", addInt64, 40, 2, 42, false},
+        {"add negative", addInt64, math.MinInt64, 1, math.MinInt64 + 1, false},
+        {"add over max", addInt64, math.MaxInt64, 1, 0, true},
+        {"add under min", addInt64, math.MinInt64, -1, 0, true},
+        {"multiply", multiplyInt64, -6, 7, -42, false},
+        {"multiply by zero", multiplyInt64, math.MinInt64, 0, 0, false},
+        {"multiply over max", multiplyInt64, math.MaxInt64, 2, 0, true},
+        {"min times minus one", multiplyInt64, math.MinInt64, -1, 0, true},
+        {"minus one times min", multiplyInt64, -1, math.MinInt64, 0, true},
+        {"min times one", multiplyInt64, math.MinInt64, 1, math.MinInt64, false},
+    }
+
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            result, overflow := test.operation(test.a, test.b)
+            if overflow != test.overflow || !overflow && result != test.result {
+                t.Errorf("result %d, overflow %v, expected %d and %v", result, overflow, test.result, test.overflow)
+            }
+        })
+    }
+}
+
+func newArithmeticProgram(t *testing.T, source string, arithmetic ArithmeticMode) *Program {
+    code, err := Assemble(source)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    program := &Program{Quiet: true, Arithmetic: arithmetic, Output: &SliceOutput{}}
+    program.LoadCode(code)
+    return program
+}
+
+func TestCheckedArithmetic(t *testing.T) {
+    tests := []struct {
+        name     string
+        source   string
+        expected OverflowError
+    }{
+        {
+            name:     "add",
+            source:   "ADD #1, #2, [r]\nADD [max], #1, [r]\nHLT\nmax: data 9223372036854775807\nr: data 0",
+            expected: OverflowError{Address: 4, Operation: Add, A: math.MaxInt64, B: 1},
+        },
+        {
+            name:     "min times minus one",
+            source:   "MUL [min], #-1, [r]\nHLT\nmin: data -9223372036854775808\nr: data 0",
+            expected: OverflowError{Address: 0, Operation: Multiply, A: math.MinInt64, B: -1},
+        },
+    }
+
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            p := newArithmeticProgram(t, test.source, CheckedArithmetic)
+
+            var overflow *OverflowError
+            if err := p.Execute(); !errors.As(err, &overflow) {
+                t.Fatalf("expected overflow, got %v", err)
+            }
+            if *overflow != test.expected {
+                t.Errorf("overflow %+v, expected %+v", *overflow, test.expected)
+            }
+        })
+    }
+
+    // Wrapping arithmetic keeps the result of Go
+    p := newArithmeticProgram(t, tests[1].source, WrappingArithmetic)
+    if err := p.Execute(); err != nil {
+        t.Fatal(err)
+    }
+    if value, _ := p.ReadMemory(6); value != math.MinInt64 {
+        t.Errorf("wrapped result %d, expected %d", value, int64(math.MinInt64))
+    }
+}
+
+// Squares the max int64 into big, compares it, jumps on it and writes it
+const bigSource = `
+    MUL  [max], [max], [big]
+    LT   [big], [max], [less]
+    EQ   [big], [big], [equal]
+    JT   [big], #jump
+    HLT
+jump:
+    OUT  [big]
+    OUT  [less]
+    OUT  [equal]
+    HLT
+max:   data 9223372036854775807
+big:   data 0
+less:  data 0
+equal: data 0
+`
+
+func TestBigArithmetic(t *testing.T) {
+    p := newArithmeticProgram(t, bigSource, BigArithmetic)
+    var written bytes.Buffer
+    p.Output = NewWriterOutput(&written)
+    if err := p.Execute(); err != nil {
+        t.Fatal(err)
+    }
+
+    expected := "85070591730234615847396907784232501249\n0\n1\n"
+    if written.String() != expected {
+        t.Errorf("outputs %q, expected %q", written.String(), expected)
+    }
+
+    // Address of big
+    big, err := p.ReadBigMemory(24)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if big.String() != "85070591730234615847396907784232501249" {
+        t.Errorf("big value %s in memory", big)
+    }
+    if value, _ := p.ReadMemory(24); value != 0 {
+        t.Errorf("big value reads as %d, expected 0", value)
+    }
+}
+
+func TestBigArithmeticAddress(t *testing.T) {
+    tests := []struct {
+        name     string
+        source   string
+        expected OverflowError
+    }{
+        {
+            name:     "output without big output",
+            source:   "MUL [max], [max], [big]\nOUT [big]\nHLT\nmax: data 9223372036854775807\nbig: data 0",
+            expected: OverflowError{Address: 4, Operation: Write},
+        },
+        {
+            name:     "relative base",
+            source:   "MUL [max], [max], [big]\nARB [big]\nHLT\nmax: data 9223372036854775807\nbig: data 0",
+            expected: OverflowError{Address: 4, Operation: SetRelativeBase},
+        },
+        {
+            name:     "jump target",
+            source:   "MUL [max], [max], [big]\nJT #1, [big]\nHLT\nmax: data 9223372036854775807\nbig: data 0",
+            expected: OverflowError{Address: 4, Operation: JumpIfTrue},
+        },
+        {
+            // The first instruction writes the big value into the address parameter of the second one
+            name:     "parameter address",
+            source:   "MUL [max], [max], [5]\nOUT [0]\nHLT\nmax: data 9223372036854775807",
+            expected: OverflowError{Address: 4, Operation: Write},
+        },
+    }
+
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            p := newArithmeticProgram(t, test.source, BigArithmetic)
+
+            var overflow *OverflowError
+            if err := p.Execute(); !errors.As(err, &overflow) {
+                t.Fatalf("expected overflow, got %v", err)
+            }
+            if *overflow != test.expected {
+                t.Errorf("overflow %+v, expected %+v", *overflow, test.expected)
+            }
+        })
+    }
+}
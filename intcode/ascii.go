@@ -0,0 +1,186 @@
+package intcode
+
+import (
+    "bufio"
+    "context"
+    "fmt"
+    "io"
+    "strings"
+)
+
+// Highest value that is considered to be an ASCII character in the output
+const maxASCIIValue = 127
+
+// EncodeASCII converts the line into codes of its runes followed by the new line, as expected by ASCII programs.
+func EncodeASCII(line string) []int64 {
+    values := make([]int64, 0, len(line) + 1)
+    for _, r := range line {
+        values = append(values, int64(r))
+    }
+    return append(values, '\n')
+}
+
+// ASCIIInput provides lines of text to the program, one character at a time. Lines pushed with PushLine are consumed
+// first, when they run out the next line is read from the reader (if there is any). Waiting for the reader ends when
+// the context is done, the line read afterwards is used by the next ReadInput.
+type ASCIIInput struct {
+    Prompt       string
+    PromptWriter io.Writer
+    // When set, its unfinished line is flushed before reading from the reader, so the prompt of the program is visible
+    Output       *ASCIIOutput
+
+    values []int64
+    reader *bufio.Reader
+    read   backgroundRead
+}
+
+func NewASCIIInput(lines ...string) *ASCIIInput {
+    input := &ASCIIInput{}
+    input.PushLine(lines...)
+    return input
+}
+
+// NewASCIIReaderInput creates ASCIIInput that reads lines from the reader (like Standard Input of a terminal).
+func NewASCIIReaderInput(r io.Reader) *ASCIIInput {
+    return &ASCIIInput{reader: bufio.NewReader(r)}
+}
+
+// PushLine queues the lines, each of them is terminated by the new line
+func (a *ASCIIInput) PushLine(lines ...string) {
+    for _, line := range lines {
+        a.values = append(a.values, EncodeASCII(line)...)
+    }
+}
+
+// Len returns number of characters waiting in the queue
+func (a *ASCIIInput) Len() int {
+    return len(a.values)
+}
+
+// Pending returns copy of the characters waiting in the queue, lines not yet read from the reader are not included
+func (a *ASCIIInput) Pending() []int64 {
+    return append([]int64(nil), a.values...)
+}
+
+func (a *ASCIIInput) setPending(values []int64) {
+    a.values = append([]int64(nil), values...)
+}
+
+func (a *ASCIIInput) ReadInput(ctx context.Context) (int64, error) {
+    if len(a.values) == 0 {
+        if err := a.readLine(ctx); err != nil {
+            return 0, err
+        }
+    }
+
+    value := a.values[0]
+    a.values = a.values[1:]
+    return value, nil
+}
+
+func (a *ASCIIInput) readLine(ctx context.Context) error {
+    if a.reader == nil {
+        return ErrNoInput
+    }
+
+    if !a.read.started() {
+        if a.Output != nil {
+            if err := a.Output.Flush(); err != nil {
+                return err
+            }
+        }
+        if a.PromptWriter != nil {
+            fmt.Fprint(a.PromptWriter, a.Prompt)
+        }
+    }
+
+    line, err := a.read.wait(ctx, func() (string, error) {
+        line, err := a.reader.ReadString('\n')
+        if err == io.EOF && line == "" {
+            return "", ErrNoInput
+        } else if err != nil && err != io.EOF {
+            return "", err
+        }
+        return line, nil
+    })
+    if err != nil {
+        return err
+    }
+
+    a.PushLine(strings.TrimRight(line, "\r\n"))
+    return nil
+}
+
+// ASCIIOutput buffers characters written by the program into lines of text. Values that are not ASCII characters
+// are results of the program rather than text, they are collected separately in Values.
+type ASCIIOutput struct {
+    // Finished lines without the new line characters
+    Lines  []string
+    Values []int64
+
+    writer io.Writer
+    line   strings.Builder
+    // Length of the unfinished line already rendered by Flush
+    flushed int
+}
+
+// NewASCIIOutput creates ASCIIOutput that also renders every finished line and value to the writer (which can be nil).
+func NewASCIIOutput(w io.Writer) *ASCIIOutput {
+    return &ASCIIOutput{writer: w}
+}
+
+func (a *ASCIIOutput) WriteOutput(ctx context.Context, value int64) error {
+    if value < 0 || value > maxASCIIValue {
+        a.Values = append(a.Values, value)
+        if a.writer != nil {
+            if _, err := fmt.Fprintf(a.writer, "non-ASCII output: %d\n", value); err != nil {
+                return err
+            }
+        }
+        return nil
+    }
+
+    if value != '\n' {
+        a.line.WriteByte(byte(value))
+        return nil
+    }
+
+    line := a.line.String()
+    flushed := a.flushed
+    a.line.Reset()
+    a.flushed = 0
+    a.Lines = append(a.Lines, line)
+
+    if a.writer != nil {
+        _, err := fmt.Fprintln(a.writer, line[flushed:])
+        return err
+    }
+    return nil
+}
+
+// Pending returns the unfinished line that has not been terminated by the new line yet
+func (a *ASCIIOutput) Pending() string {
+    return a.line.String()
+}
+
+// Flush renders the unfinished line to the writer, the line stays pending until the program finishes it.
+func (a *ASCIIOutput) Flush() error {
+    if a.writer == nil || a.line.Len() == a.flushed {
+        return nil
+    }
+
+    _, err := io.WriteString(a.writer, a.line.String()[a.flushed:])
+    a.flushed = a.line.Len()
+    return err
+}
+
+// Text returns all the finished lines followed by the unfinished one
+func (a *ASCIIOutput) Text() string {
+    var text strings.Builder
+    for _, line := range a.Lines {
+        text.WriteString(line)
+        text.WriteByte('\n')
+    }
+    text.WriteString(a.line.String())
+    return text.String()
+}
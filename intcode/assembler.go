@@ -0,0 +1,215 @@
+package intcode
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+)
+
+// DataDirective places its comma separated values directly into the program
+const DataDirective = "data"
+
+var (
+    // Operations can be written either as short mnemonics produced by the disassembler or by their full names
+    operationNames = map[string]InstructionOperation{
+        "add": Add, "multiply": Multiply, "read": Read, "write": Write, "jumpiftrue": JumpIfTrue,
+        "jumpiffalse": JumpIfFalse, "lessthan": LessThan, "equals": Equals, "setrelativebase": SetRelativeBase,
+        "terminate": Terminate,
+    }
+)
+
+func init() {
+    for operation, mnemonic := range Mnemonics {
+        operationNames[strings.ToLower(mnemonic)] = operation
+    }
+}
+
+// Single line of assembly with its label references still unresolved
+type statement struct {
+    line      int
+    address   int
+    operation InstructionOperation
+    operands  []string
+    data      bool
+}
+
+// Assemble translates the textual Intcode assembly into program code.
+//
+// Each line holds an optional label ("loop:"), an optional instruction and an optional comment (starting with ";").
+// Instruction is a mnemonic (ADD, MUL, IN, OUT, JT, JF, LT, EQ, ARB, HLT or full operation names like JumpIfTrue)
+// followed by comma separated operands: "[x]" for position mode, "#x" for immediate mode and "rb+x" / "rb-x"
+// for relative mode. Operand value is a number or a label, optionally with offset ("loop+1").
+// The "data" directive places its comma separated numbers or label addresses directly into the code.
+func Assemble(source string) ([]int64, error) {
+    labels := map[string]int{}
+    var statements []statement
+    address := 0
+
+    for lineIndex, line := range strings.Split(source, "\n") {
+        lineNumber := lineIndex + 1
+
+        if comment := strings.Index(line, ";"); comment >= 0 {
+            line = line[:comment]
+        }
+        line = strings.TrimSpace(line)
+
+        if colon := strings.Index(line, ":"); colon >= 0 {
+            label := strings.TrimSpace(line[:colon])
+            if !isLabelName(label) {
+                return nil, fmt.Errorf("line %d: invalid label %q", lineNumber, label)
+            }
+            if _, exists := labels[label]; exists {
+                return nil, fmt.Errorf("line %d: duplicate label %q", lineNumber, label)
+            }
+            labels[label] = address
+            line = strings.TrimSpace(line[colon+1:])
+        }
+
+        if line == "" {
+            continue
+        }
+
+        name, rest := line, ""
+        if space := strings.IndexAny(line, " \t"); space >= 0 {
+            name, rest = line[:space], strings.TrimSpace(line[space:])
+        }
+
+        var operands []string
+        if rest != "" {
+            for _, operand := range strings.Split(rest, ",") {
+                operands = append(operands, strings.TrimSpace(operand))
+            }
+        }
+
+        if strings.ToLower(name) == DataDirective {
+            if len(operands) == 0 {
+                return nil, fmt.Errorf("line %d: data directive without values", lineNumber)
+            }
+            statements = append(statements, statement{line: lineNumber, address: address, operands: operands, data: true})
+            address += len(operands)
+            continue
+        }
+
+        operation, ok := operationNames[strings.ToLower(name)]
+        if !ok {
+            return nil, fmt.Errorf("line %d: unknown mnemonic %q", lineNumber, name)
+        }
+        if len(operands) != InstructionLength[operation] - 1 {
+            return nil, fmt.Errorf("line %d: %s expects %d operands, got %d", lineNumber, Mnemonics[operation], InstructionLength[operation] - 1, len(operands))
+        }
+
+        statements = append(statements, statement{line: lineNumber, address: address, operation: operation, operands: operands})
+        address += InstructionLength[operation]
+    }
+
+    code := make([]int64, 0, address)
+    for _, s := range statements {
+        if s.data {
+            for _, operand := range s.operands {
+                value, err := resolveValue(operand, labels)
+                if err != nil {
+                    return nil, fmt.Errorf("line %d: %v", s.line, err)
+                }
+                code = append(code, value)
+            }
+            continue
+        }
+
+        instruction := Instruction{Operation: s.operation, Length: InstructionLength[s.operation]}
+        for _, operand := range s.operands {
+            param, err := parseOperand(operand, labels)
+            if err != nil {
+                return nil, fmt.Errorf("line %d: %v", s.line, err)
+            }
+            instruction.Params = append(instruction.Params, param)
+        }
+
+        if instruction.doesStoreOutputInMemory() && instruction.Params[len(instruction.Params)-1].Mode == ImmediateMode {
+            return nil, fmt.Errorf("line %d: %s can not write to immediate operand", s.line, Mnemonics[s.operation])
+        }
+
+        code = append(code, instruction.Encode()...)
+    }
+
+    return code, nil
+}
+
+// Encode builds the instruction code (operation with parameter modes) followed by parameter values.
+func (i *Instruction) Encode() []int64 {
+    opCode := int64(i.Operation)
+    for j, param := range i.Params {
+        opCode += int64(param.Mode * pow10(j+2))
+    }
+
+    code := []int64{opCode}
+    for _, param := range i.Params {
+        code = append(code, param.Value)
+    }
+
+    return code
+}
+
+// FormatCode renders the program in comma separated format used by the puzzle input files.
+func FormatCode(code []int64) string {
+    values := make([]string, len(code))
+    for i, value := range code {
+        values[i] = strconv.FormatInt(value, 10)
+    }
+
+    return strings.Join(values, ",")
+}
+
+func parseOperand(operand string, labels map[string]int) (InstructionParam, error) {
+    switch {
+    case strings.HasPrefix(operand, "[") && strings.HasSuffix(operand, "]"):
+        value, err := resolveValue(operand[1:len(operand)-1], labels)
+        return InstructionParam{Mode: PositionMode, Value: value}, err
+    case strings.HasPrefix(operand, "#"):
+        value, err := resolveValue(operand[1:], labels)
+        return InstructionParam{Mode: ImmediateMode, Value: value}, err
+    case strings.HasPrefix(operand, "rb+"):
+        value, err := resolveValue(operand[3:], labels)
+        return InstructionParam{Mode: RelativeMode, Value: value}, err
+    case strings.HasPrefix(operand, "rb-"):
+        value, err := resolveValue(operand[3:], labels)
+        return InstructionParam{Mode: RelativeMode, Value: -value}, err
+    default:
+        return InstructionParam{}, fmt.Errorf("operand %q has no mode marker ([x], #x or rb+x)", operand)
+    }
+}
+
+// Resolves a number, a label or a label with numeric offset (label+1, label-2)
+func resolveValue(value string, labels map[string]int) (int64, error) {
+    value = strings.TrimSpace(value)
+    if number, err := strconv.ParseInt(value, 10, 64); err == nil {
+        return number, nil
+    }
+
+    label, offset := value, int64(0)
+    if split := strings.LastIndexAny(value, "+-"); split > 0 {
+        number, err := strconv.ParseInt(strings.TrimSpace(value[split:]), 10, 64)
+        if err != nil {
+            return 0, fmt.Errorf("invalid offset in %q", value)
+        }
+        label, offset = strings.TrimSpace(value[:split]), number
+    }
+
+    address, ok := labels[label]
+    if !ok {
+        return 0, fmt.Errorf("undefined label %q", label)
+    }
+
+    return int64(address) + offset, nil
+}
+
+func isLabelName(name string) bool {
+    if name == "" {
+        return false
+    }
+    for i, r := range name {
+        if r != '_' && !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(i > 0 && r >= '0' && r <= '9') {
+            return false
+        }
+    }
+    return true
+}
@@ -0,0 +1,95 @@
+package intcode
+
+import (
+    "reflect"
+    "strings"
+    "testing"
+)
+
+func TestAssemble(t *testing.T) {
+    tests := []struct {
+        name     string
+        source   string
+        expected []int64
+    }{
+        {
+            name:     "modes",
+            source:   "ADD [4], #-2, rb+3\nMUL rb-1, #7, [0]\nHLT",
+            expected: []int64{21001, 4, -2, 3, 1202, -1, 7, 0, 99},
+        },
+        {
+            name:     "labels",
+            source:   "loop: JT [flag], #loop\nJF #0, #end\nend: HLT\nflag: data 1",
+            expected: []int64{1005, 7, 0, 1106, 0, 6, 99, 1},
+        },
+        {
+            // Label used before it is defined and labels with offsets
+            name:     "label offsets",
+            source:   "OUT [value+1]\nADD [value], #1, [value-1]\nHLT\nvalue: data 5, 6",
+            expected: []int64{4, 8, 1001, 7, 1, 6, 99, 5, 6},
+        },
+        {
+            name:     "data with labels",
+            source:   "start: HLT\ntable: data start, table, end+1, -3\nend: data 0",
+            expected: []int64{99, 0, 1, 6, -3, 0},
+        },
+        {
+            name:     "full operation names",
+            source:   "Read [0]\nwrite #1\njumpiftrue #0, #0\nJumpIfFalse #1, #0\nlessthan #1, #2, [0]\n" +
+                "equals #1, #1, [0]\nSetRelativeBase #3\nmultiply #2, #3, rb+0\nadd #1, #1, [0]\nTerminate",
+            expected: []int64{3, 0, 104, 1, 1105, 0, 0, 1106, 1, 0, 1107, 1, 2, 0, 1108, 1, 1, 0, 109, 3,
+                21102, 2, 3, 0, 1101, 1, 1, 0, 99},
+        },
+        {
+            name:     "comments and empty lines",
+            source:   "; program\n\n   IN [0] ; read\nlabel:\n  OUT [label] ; write\n",
+            expected: []int64{3, 0, 4, 2},
+        },
+    }
+
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            code, err := Assemble(test.source)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if !reflect.DeepEqual(code, test.expected) {
+                t.Errorf("code %v, expected %v", code, test.expected)
+            }
+        })
+    }
+}
+
+func TestAssembleErrors(t *testing.T) {
+    tests := []struct {
+        name   string
+        source string
+        // Part of the error message
+        error  string
+    }{
+        {"duplicate label", "a: HLT\na: HLT", `line 2: duplicate label "a"`},
+        {"undefined label", "HLT\nOUT [missing]", `line 2: undefined label "missing"`},
+        {"undefined label in data", "data end", `line 1: undefined label "end"`},
+        {"invalid label", "1a: HLT", `line 1: invalid label "1a"`},
+        {"missing operand", "ADD [1], [2]", "line 1: ADD expects 3 operands, got 2"},
+        {"extra operand", "HLT #1", "line 1: HLT expects 0 operands, got 1"},
+        {"immediate write", "IN #5", "line 1: IN can not write to immediate operand"},
+        {"immediate result", "HLT\nADD [1], [2], #3", "line 2: ADD can not write to immediate operand"},
+        {"unknown mnemonic", "DIV [1], [2], [3]", `line 1: unknown mnemonic "DIV"`},
+        {"operand without mode", "OUT 5", `line 1: operand "5" has no mode marker`},
+        {"invalid offset", "a: OUT [a+b]", `line 1: invalid offset in "a+b"`},
+        {"empty data", "data", "line 1: data directive without values"},
+    }
+
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            code, err := Assemble(test.source)
+            if err == nil {
+                t.Fatalf("assembled into %v", code)
+            }
+            if !strings.Contains(err.Error(), test.error) {
+                t.Errorf("error %q, expected %q", err, test.error)
+            }
+        })
+    }
+}
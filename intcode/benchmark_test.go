@@ -0,0 +1,192 @@
+package intcode
+
+import (
+    "math"
+    "testing"
+)
+
+// Runs the BOOST program of day 9 in sensor boost mode, which executes a few hundred thousand instructions
+func benchmarkDay9(b *testing.B, compiled bool) {
+    code, err := ReadCodeFile("../9/code")
+    if err != nil {
+        b.Fatal(err)
+    }
+
+    b.ReportAllocs()
+    b.ResetTimer()
+
+    for n := 0; n < b.N; n++ {
+        output := &SliceOutput{}
+        program := &Program{Quiet: true, Input: NewQueueInput(2), Output: output, Compiled: compiled}
+        program.LoadCode(code)
+
+        if err := program.Execute(); err != nil {
+            b.Fatal(err)
+        }
+        if len(output.Values) != 1 {
+            b.Fatal("unexpected output", output.Values)
+        }
+    }
+}
+
+// Interpreter loop used before the decode cache: every step decodes the instruction again by legacyInitialize
+func legacyExecute(p *Program) error {
+    for !p.Completed {
+        var instruction Instruction
+        legacyInitialize(&instruction, p.Memory, p.Position)
+        p.Steps++
+
+        p.loadParameterValues(&instruction)
+        if p.fault != nil {
+            return p.fault
+        }
+
+        switch instruction.Operation {
+        case Add:
+            p.doAdd(&instruction)
+        case Multiply:
+            p.doMultiply(&instruction)
+        case Read:
+            p.doReadInput(&instruction)
+        case Write:
+            p.doWriteOutput(&instruction)
+        case JumpIfTrue:
+            p.doJumpIfTrue(&instruction)
+        case JumpIfFalse:
+            p.doJumpIfFalse(&instruction)
+        case LessThan:
+            p.doComparisonLessThan(&instruction)
+        case Equals:
+            p.doComparisonEquals(&instruction)
+        case SetRelativeBase:
+            p.doUpdateRelativeBase(&instruction)
+        case Terminate:
+            p.complete()
+        default:
+            return &InvalidOpcodeError{Address: p.Position, Value: p.Memory[p.Position]}
+        }
+
+        if p.fault != nil {
+            return p.fault
+        }
+    }
+
+    return nil
+}
+
+// Baseline of BenchmarkExecuteDay9, the whole run with the decoder used before the decode cache
+func BenchmarkExecuteDay9Legacy(b *testing.B) {
+    code, err := ReadCodeFile("../9/code")
+    if err != nil {
+        b.Fatal(err)
+    }
+
+    b.ReportAllocs()
+    b.ResetTimer()
+
+    for n := 0; n < b.N; n++ {
+        output := &SliceOutput{}
+        program := &Program{Quiet: true, Input: NewQueueInput(2), Output: output}
+        program.LoadCode(code)
+
+        if err := legacyExecute(program); err != nil {
+            b.Fatal(err)
+        }
+        if len(output.Values) != 1 || output.Values[0] != 83089 {
+            b.Fatal("unexpected output", output.Values)
+        }
+    }
+}
+
+func BenchmarkExecuteDay9(b *testing.B) {
+    benchmarkDay9(b, false)
+}
+
+func BenchmarkExecuteDay9Compiled(b *testing.B) {
+    benchmarkDay9(b, true)
+}
+
+// Decoder used before the decode cache, it allocates the parameters and computes the mode divisors by math.Pow
+func legacyInitialize(i *Instruction, intCode []int64, pIndex int) {
+    instValue := int(intCode[pIndex])
+
+    i.Operation = InstructionOperation(instValue)
+
+    evalParamModes := false
+    if instValue >= 100 {
+        i.Operation = InstructionOperation(instValue % 100)
+        evalParamModes = true
+    }
+
+    i.Length = InstructionLength[i.Operation]
+    paramCount := i.Length - 1
+    if paramCount < 0 {
+        paramCount = 0
+    }
+    i.Params = make([]InstructionParam, paramCount, paramCount)
+
+    for j := 0; j < paramCount; j++ {
+        i.Params[j] = InstructionParam{PositionMode, intCode[pIndex+j+1]}
+        if evalParamModes {
+            i.Params[j].Mode = (instValue / int(math.Pow(float64(10), float64(j+2)))) % 10
+        }
+    }
+}
+
+// Addresses of the instructions of day 9 code, they are decoded in turns by the decode benchmarks
+func day9Instructions(b *testing.B) ([]int64, []int) {
+    code, err := ReadCodeFile("../9/code")
+    if err != nil {
+        b.Fatal(err)
+    }
+
+    var addresses []int
+    for _, line := range Disassemble(code) {
+        if line.Mnemonic != DataMnemonic {
+            addresses = append(addresses, line.Address)
+        }
+    }
+    return code, addresses
+}
+
+func BenchmarkDecodeDay9Legacy(b *testing.B) {
+    code, addresses := day9Instructions(b)
+    var instruction Instruction
+
+    b.ReportAllocs()
+    b.ResetTimer()
+    for n := 0; n < b.N; n++ {
+        legacyInitialize(&instruction, code, addresses[n % len(addresses)])
+    }
+}
+
+func BenchmarkDecodeDay9(b *testing.B) {
+    code, addresses := day9Instructions(b)
+    var instruction Instruction
+
+    b.ReportAllocs()
+    b.ResetTimer()
+    for n := 0; n < b.N; n++ {
+        instruction.Initialize(code, addresses[n % len(addresses)])
+    }
+}
+
+func BenchmarkDecodeDay9Cached(b *testing.B) {
+    code, addresses := day9Instructions(b)
+    program := &Program{Quiet: true}
+    program.LoadCode(code)
+    for _, address := range addresses {
+        program.Position = address
+        instruction, word, _ := program.decode()
+        program.cacheInstruction(instruction, word)
+    }
+
+    b.ReportAllocs()
+    b.ResetTimer()
+    for n := 0; n < b.N; n++ {
+        program.Position = addresses[n % len(addresses)]
+        if _, _, cached := program.decode(); !cached {
+            b.Fatal("instruction was not cached at address", program.Position)
+        }
+    }
+}
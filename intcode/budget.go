@@ -0,0 +1,52 @@
+package intcode
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+)
+
+// Returns the cost of the operation, operations without configured cost cost 1
+func (p *Program) cost(operation InstructionOperation) int64 {
+    if cost, ok := p.Costs[operation]; ok {
+        return cost
+    }
+    return 1
+}
+
+// Charges the cost of the instruction before it is executed. The instruction is not executed when its cost
+// exceeds the rest of the budget and the program halts instead of completing, so it can continue once the budget
+// is raised and Halt cleared.
+func (p *Program) charge(operation InstructionOperation) error {
+    cost := p.cost(operation)
+    if p.Budget > 0 && p.BudgetUsed + cost > p.Budget {
+        p.fault = &BudgetExhaustedError{Address: p.Position, Budget: p.Budget, Used: p.BudgetUsed, Cost: cost}
+        p.Halt = true
+        return p.fault
+    }
+
+    p.BudgetUsed += cost
+    return nil
+}
+
+// ParseCosts reads costs of the operations in the form "mnemonic=cost,...", like "MUL=3,IN=10"
+func ParseCosts(s string) (map[InstructionOperation]int64, error) {
+    costs := map[InstructionOperation]int64{}
+    for _, item := range strings.Split(s, ",") {
+        parts := strings.Split(strings.TrimSpace(item), "=")
+        if len(parts) != 2 {
+            return nil, fmt.Errorf("invalid cost %q, use mnemonic=cost", item)
+        }
+
+        operation, ok := operationNames[strings.ToLower(parts[0])]
+        if !ok {
+            return nil, fmt.Errorf("unknown operation %q", parts[0])
+        }
+        cost, err := strconv.ParseInt(parts[1], 10, 64)
+        if err != nil || cost < 0 {
+            return nil, fmt.Errorf("invalid cost %q of %s", parts[1], parts[0])
+        }
+        costs[operation] = cost
+    }
+    return costs, nil
+}
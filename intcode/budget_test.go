@@ -0,0 +1,109 @@
+package intcode
+
+import (
+    "errors"
+    "reflect"
+    "testing"
+)
+
+func TestParseCosts(t *testing.T) {
+    tests := []struct {
+        text     string
+        expected map[InstructionOperation]int64
+        fails    bool
+    }{
+        {text: "MUL=3,IN=10", expected: map[InstructionOperation]int64{Multiply: 3, Read: 10}},
+        {text: "mul=3, out=0", expected: map[InstructionOperation]int64{Multiply: 3, Write: 0}},
+        {text: "multiply=2,setrelativebase=5", expected: map[InstructionOperation]int64{Multiply: 2, SetRelativeBase: 5}},
+        {text: "MUL", fails: true},
+        {text: "MUL=3=4", fails: true},
+        {text: "DIV=3", fails: true},
+        {text: "MUL=x", fails: true},
+        {text: "MUL=-1", fails: true},
+        {text: "", fails: true},
+    }
+
+    for _, test := range tests {
+        t.Run(test.text, func(t *testing.T) {
+            costs, err := ParseCosts(test.text)
+            if test.fails {
+                if err == nil {
+                    t.Errorf("parsed as %v", costs)
+                }
+                return
+            }
+            if err != nil {
+                t.Fatal(err)
+            }
+            if !reflect.DeepEqual(costs, test.expected) {
+                t.Errorf("costs %v, expected %v", costs, test.expected)
+            }
+        })
+    }
+}
+
+func TestCharge(t *testing.T) {
+    p := &Program{Budget: 5, Costs: map[InstructionOperation]int64{Multiply: 3}}
+    for _, operation := range []InstructionOperation{Multiply, Add} {
+        if err := p.charge(operation); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if p.BudgetUsed != 4 {
+        t.Errorf("budget used %d, expected 4", p.BudgetUsed)
+    }
+
+    // Refused instruction is not charged
+    var exhausted *BudgetExhaustedError
+    if err := p.charge(Multiply); !errors.As(err, &exhausted) {
+        t.Fatalf("expected exhausted budget, got %v", err)
+    }
+    if *exhausted != (BudgetExhaustedError{Budget: 5, Used: 4, Cost: 3}) || !p.Halt || p.BudgetUsed != 4 {
+        t.Errorf("error %+v, halt %v, budget used %d", *exhausted, p.Halt, p.BudgetUsed)
+    }
+    if err := p.charge(Add); err != nil {
+        t.Errorf("instruction within the budget refused: %v", err)
+    }
+
+    // Costs without budget are only counted
+    p = &Program{Costs: map[InstructionOperation]int64{Multiply: 3}}
+    for k := 0; k < 10; k++ {
+        if err := p.charge(Multiply); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if p.BudgetUsed != 30 {
+        t.Errorf("budget used %d, expected 30", p.BudgetUsed)
+    }
+}
+
+func TestBudgetResume(t *testing.T) {
+    code, err := Assemble(loopSource)
+    if err != nil {
+        t.Fatal(err)
+    }
+    costs := map[InstructionOperation]int64{Read: 10}
+
+    // The loop reads three values, it costs 3 * (10 + 4) + 1
+    p := &Program{Quiet: true, Input: NewQueueInput(1, 2, 3), Output: &SliceOutput{}, Budget: 20, Costs: costs}
+    p.LoadCode(code)
+
+    var exhausted *BudgetExhaustedError
+    if err := p.Execute(); !errors.As(err, &exhausted) {
+        t.Fatalf("expected exhausted budget, got %v", err)
+    }
+    if p.Completed || !p.Halt || p.BudgetUsed != 14 || exhausted.Address != 0 {
+        t.Fatalf("completed %v, halt %v, budget used %d at address %d", p.Completed, p.Halt, p.BudgetUsed, exhausted.Address)
+    }
+    checkOutputs(t, p, 2)
+
+    p.Budget = 100
+    p.Halt = false
+    if err := p.Execute(); err != nil {
+        t.Fatal(err)
+    }
+    if !p.Completed || p.BudgetUsed != 43 {
+        t.Errorf("completed %v, budget used %d, expected 43", p.Completed, p.BudgetUsed)
+    }
+    checkOutputs(t, p, 2, 3, 4)
+}
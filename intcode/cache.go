@@ -0,0 +1,71 @@
+package intcode
+
+// Maximum number of parameters of a single instruction
+const maxParams = 3
+
+// Valid instruction decoded from the word on its address, it is kept until the address is written to
+type cachedInstruction struct {
+    word      int64
+    operation InstructionOperation
+    length    int
+    modes     [maxParams]int
+    valid     bool
+}
+
+// Decodes the instruction on the current position into the reused instruction of the program. Instructions that
+// pass validation are cached by their address (within the dense memory), the following executions of the same
+// address only load the parameter values. Cached entries are dropped by writes to their address (see WriteMemory),
+// the word is compared as well so the cache stays correct even when the caller changes Memory directly.
+// It returns the decoded instruction, the word it was decoded from and whether it was found in the cache.
+func (p *Program) decode() (*Instruction, int64, bool) {
+    i := &p.instruction
+    if cap(i.Params) < maxParams {
+        i.Params = make([]InstructionParam, 0, maxParams)
+    }
+    words := p.instructionWords(p.Position)
+
+    if p.Position < len(p.decodeCache) {
+        if cached := &p.decodeCache[p.Position]; cached.valid && cached.word == words[0] {
+            i.Operation = cached.operation
+            i.Length = cached.length
+            i.Params = i.Params[:cached.length - 1]
+            for j := range i.Params {
+                i.Params[j] = InstructionParam{cached.modes[j], words[j+1]}
+            }
+            return i, words[0], true
+        }
+    }
+
+    i.Initialize(words, 0)
+    return i, words[0], false
+}
+
+// Stores the decoded instruction on the current position in the cache, it has to be valid
+func (p *Program) cacheInstruction(i *Instruction, word int64) {
+    if p.Position >= len(p.Memory) {
+        return
+    }
+
+    if len(p.decodeCache) < len(p.Memory) {
+        cache := make([]cachedInstruction, len(p.Memory))
+        copy(cache, p.decodeCache)
+        p.decodeCache = cache
+    }
+
+    cached := cachedInstruction{word: word, operation: i.Operation, length: i.Length, valid: true}
+    for j, param := range i.Params {
+        cached.modes[j] = param.Mode
+    }
+    p.decodeCache[p.Position] = cached
+}
+
+// Drops the cached instruction on the address that is being written to, as well as the compiled instructions
+// containing the address
+func (p *Program) invalidateInstruction(address int64) {
+    if address < int64(len(p.decodeCache)) {
+        p.decodeCache[address].valid = false
+    }
+    if p.compiled != nil {
+        p.compiled.invalidate(address)
+    }
+}
@@ -0,0 +1,305 @@
+package intcode
+
+import (
+    "bufio"
+    "encoding/json"
+    "fmt"
+    "io"
+    "sort"
+    "strings"
+)
+
+// EdgeKind tells how the control gets from one basic block to another
+type EdgeKind int
+
+const (
+    // Execution continues with the following instruction
+    FallthroughEdge EdgeKind = iota
+    // Jump with target given in immediate mode
+    JumpEdge
+    // Jump with target read from memory, it is not known without running the program (To is UnknownTarget)
+    IndirectEdge
+    // Jump observed while running the program that is not known statically
+    DynamicEdge
+)
+
+// UnknownTarget is the target of indirect edges
+const UnknownTarget = -1
+
+// Edge leads from the last instruction of a basic block to the start of another one. Count is the number of times
+// it was taken in the merged runs.
+type Edge struct {
+    To    int
+    Kind  EdgeKind
+    Count int64
+}
+
+// BasicBlock is a sequence of instructions that is always executed from the first to the last one
+type BasicBlock struct {
+    Start int
+    Lines []DisassembledLine
+    Edges []Edge
+}
+
+// End returns the address following the last instruction of the block
+func (b *BasicBlock) End() int {
+    last := b.Lines[len(b.Lines) - 1]
+    return last.Address + len(last.Words)
+}
+
+// ControlFlowGraph splits the instructions reachable from the first address into basic blocks. Targets of jumps
+// observed in runs (see MergeProfile and MergeTrace) are explored as well, so the graph grows with the runs.
+type ControlFlowGraph struct {
+    // Blocks ordered by their start address
+    Blocks []*BasicBlock
+
+    code    []int64
+    // Addresses the exploration starts from, the first address and the targets of dynamic jumps
+    entries map[int]bool
+    jumps   map[Jump]int64
+}
+
+func NewControlFlowGraph(code []int64) *ControlFlowGraph {
+    g := &ControlFlowGraph{
+        code:    append([]int64(nil), code...),
+        entries: map[int]bool{0: true},
+        jumps:   map[Jump]int64{},
+    }
+    g.build()
+    return g
+}
+
+// Block returns the basic block containing the instruction on given address, nil when there is none
+func (g *ControlFlowGraph) Block(address int) *BasicBlock {
+    for _, block := range g.Blocks {
+        for _, line := range block.Lines {
+            if line.Address == address {
+                return block
+            }
+        }
+    }
+    return nil
+}
+
+// MergeProfile adds the jumps taken in the profiled run
+func (g *ControlFlowGraph) MergeProfile(profile *Profile) {
+    for jump, count := range profile.Jumps {
+        g.addJump(jump, count)
+    }
+    g.build()
+}
+
+// MergeTrace adds the jumps taken in the run recorded as JSON Lines trace (see Program.Trace)
+func (g *ControlFlowGraph) MergeTrace(r io.Reader) error {
+    scanner := bufio.NewScanner(r)
+    scanner.Buffer(make([]byte, 64 * 1024), 16 * 1024 * 1024)
+
+    var previous *TraceRecord
+    for line := 1; scanner.Scan(); line++ {
+        var record TraceRecord
+        if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+            return fmt.Errorf("line %d: %v", line, err)
+        }
+
+        if previous != nil && isJump(InstructionOperation(previous.OpCode)) &&
+            record.Address != previous.Address + InstructionLength[InstructionOperation(previous.OpCode)] {
+            g.addJump(Jump{From: previous.Address, To: record.Address}, 1)
+        }
+        previous = &record
+    }
+    if err := scanner.Err(); err != nil {
+        return err
+    }
+
+    g.build()
+    return nil
+}
+
+func (g *ControlFlowGraph) addJump(jump Jump, count int64) {
+    g.jumps[jump] += count
+    g.entries[jump.To] = true
+}
+
+// Successors of the instruction that are known statically, jumps with constant condition have a single one
+func staticEdges(address int, i *Instruction) []Edge {
+    next := address + i.Length
+
+    switch i.Operation {
+    case Terminate:
+        return nil
+    case JumpIfTrue, JumpIfFalse:
+        target := Edge{To: UnknownTarget, Kind: IndirectEdge}
+        if i.Params[1].Mode == ImmediateMode {
+            target = Edge{To: int(i.Params[1].Value), Kind: JumpEdge}
+        }
+
+        if i.Params[0].Mode == ImmediateMode {
+            if (i.Params[0].Value != 0) == (i.Operation == JumpIfTrue) {
+                return []Edge{target}
+            }
+            return []Edge{{To: next, Kind: FallthroughEdge}}
+        }
+        return []Edge{target, {To: next, Kind: FallthroughEdge}}
+    default:
+        return []Edge{{To: next, Kind: FallthroughEdge}}
+    }
+}
+
+func (g *ControlFlowGraph) build() {
+    instructions := map[int]Instruction{}
+    leaders := map[int]bool{}
+    var pending []int
+    for address := range g.entries {
+        pending = append(pending, address)
+        leaders[address] = true
+    }
+
+    for len(pending) > 0 {
+        address := pending[len(pending) - 1]
+        pending = pending[:len(pending) - 1]
+        if _, ok := instructions[address]; ok {
+            continue
+        }
+
+        instruction, ok := DecodeInstruction(g.code, address)
+        if !ok {
+            continue
+        }
+        instructions[address] = instruction
+
+        for _, edge := range staticEdges(address, &instruction) {
+            if edge.To == UnknownTarget {
+                continue
+            }
+            // Instructions following a jump start a new block as well as the jump targets
+            if isJump(instruction.Operation) {
+                leaders[edge.To] = true
+            }
+            pending = append(pending, edge.To)
+        }
+    }
+
+    addresses := make([]int, 0, len(instructions))
+    for address := range instructions {
+        addresses = append(addresses, address)
+    }
+    sort.Ints(addresses)
+
+    g.Blocks = nil
+    var block *BasicBlock
+    for _, address := range addresses {
+        instruction := instructions[address]
+        if block == nil || leaders[address] || block.End() != address {
+            block = &BasicBlock{Start: address}
+            g.Blocks = append(g.Blocks, block)
+        }
+        block.Lines = append(block.Lines, disassembleInstruction(g.code, address, instruction))
+
+        next := address + instruction.Length
+        _, continues := instructions[next]
+        if isJump(instruction.Operation) || instruction.Operation == Terminate || !continues || leaders[next] {
+            block.Edges = staticEdges(address, &instruction)
+            block = nil
+        }
+    }
+
+    g.addDynamicEdges()
+}
+
+// Counts the observed jumps on the static edges, the other ones are added as dynamic edges
+func (g *ControlFlowGraph) addDynamicEdges() {
+    for jump, count := range g.jumps {
+        block := g.Block(jump.From)
+        if block == nil || block.Lines[len(block.Lines) - 1].Address != jump.From {
+            continue
+        }
+
+        known := false
+        for k := range block.Edges {
+            if block.Edges[k].To == jump.To && block.Edges[k].Kind == JumpEdge {
+                block.Edges[k].Count += count
+                known = true
+            }
+        }
+        if !known {
+            block.Edges = append(block.Edges, Edge{To: jump.To, Kind: DynamicEdge, Count: count})
+        }
+    }
+
+    // Map iteration order is random, the edges are sorted to keep the output stable
+    for _, block := range g.Blocks {
+        sort.SliceStable(block.Edges, func(a, b int) bool {
+            if block.Edges[a].Kind != block.Edges[b].Kind {
+                return block.Edges[a].Kind < block.Edges[b].Kind
+            }
+            return block.Edges[a].To < block.Edges[b].To
+        })
+    }
+}
+
+// WriteDOT writes the graph in the Graphviz format. Indirect edges lead to a single node with unknown target,
+// dynamic edges are dashed and jumps taken in the merged runs are labelled by their counts.
+func (g *ControlFlowGraph) WriteDOT(w io.Writer) error {
+    var dot strings.Builder
+    dot.WriteString("digraph intcode {\n")
+    dot.WriteString("    node [shape=box, fontname=\"monospace\"];\n")
+
+    hasUnknown := false
+    invalidTargets := map[int]bool{}
+    for _, block := range g.Blocks {
+        var label strings.Builder
+        for _, line := range block.Lines {
+            fmt.Fprintf(&label, "%d: %s\\l", line.Address, line.Source())
+        }
+        fmt.Fprintf(&dot, "    b%d [label=\"%s\"];\n", block.Start, label.String())
+
+        for _, edge := range block.Edges {
+            if edge.Kind == IndirectEdge {
+                hasUnknown = true
+                fmt.Fprintf(&dot, "    b%d -> unknown [style=dotted];\n", block.Start)
+                continue
+            }
+
+            var attributes []string
+            switch edge.Kind {
+            case JumpEdge:
+                attributes = append(attributes, "color=blue")
+            case DynamicEdge:
+                attributes = append(attributes, "color=red", "style=dashed")
+            }
+            if edge.Count > 0 {
+                attributes = append(attributes, fmt.Sprintf("label=\"%d\"", edge.Count))
+            }
+            target := fmt.Sprintf("b%d", edge.To)
+            if targetBlock := g.Block(edge.To); targetBlock == nil || targetBlock.Start != edge.To {
+                target = fmt.Sprintf("invalid%d", edge.To)
+                invalidTargets[edge.To] = true
+            }
+            if len(attributes) > 0 {
+                fmt.Fprintf(&dot, "    b%d -> %s [%s];\n", block.Start, target, strings.Join(attributes, ", "))
+            } else {
+                fmt.Fprintf(&dot, "    b%d -> %s;\n", block.Start, target)
+            }
+        }
+    }
+
+    if hasUnknown {
+        dot.WriteString("    unknown [shape=ellipse, label=\"?\"];\n")
+    }
+    for _, address := range sortedKeys(invalidTargets) {
+        fmt.Fprintf(&dot, "    invalid%d [shape=octagon, label=\"%d: invalid instruction\"];\n", address, address)
+    }
+    dot.WriteString("}\n")
+
+    _, err := io.WriteString(w, dot.String())
+    return err
+}
+
+func sortedKeys(set map[int]bool) []int {
+    keys := make([]int, 0, len(set))
+    for key := range set {
+        keys = append(keys, key)
+    }
+    sort.Ints(keys)
+    return keys
+}
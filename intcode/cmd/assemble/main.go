@@ -0,0 +1,48 @@
+// Assemble translates Intcode assembly into the comma separated program format.
+//
+// Usage: go run ./intcode/cmd/assemble [-o program] source.asm
+//
+// See intcode.Assemble for the description of the source format.
+package main
+
+import (
+    "flag"
+    "fmt"
+    "io/ioutil"
+    "os"
+
+    "github.com/tomasbobek/AdventOfCode19/intcode"
+)
+
+func main() {
+    output := flag.String("o", "", "write the program into this file instead of Standard Output")
+    flag.Parse()
+
+    if flag.NArg() != 1 {
+        fmt.Println("usage: assemble [-o program] <source file>")
+        os.Exit(2)
+    }
+
+    source, err := ioutil.ReadFile(flag.Arg(0))
+    if err != nil {
+        fmt.Println(err)
+        os.Exit(1)
+    }
+
+    code, err := intcode.Assemble(string(source))
+    if err != nil {
+        fmt.Println(err)
+        os.Exit(1)
+    }
+
+    if *output == "" {
+        fmt.Println(intcode.FormatCode(code))
+        return
+    }
+
+    err = ioutil.WriteFile(*output, []byte(intcode.FormatCode(code)), 0644)
+    if err != nil {
+        fmt.Println(err)
+        os.Exit(1)
+    }
+}
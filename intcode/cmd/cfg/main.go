@@ -0,0 +1,84 @@
+// Cfg writes the control flow graph of the Intcode program in the Graphviz DOT format.
+//
+// Usage: go run ./intcode/cmd/cfg [-o graph.dot] [-trace trace.jsonl] [-run] 11/code [input values...]
+//
+// Jumps taken in a run are merged into the graph, either from a trace written by the run command (-trace)
+// or by running the program with given input values (-run).
+package main
+
+import (
+    "flag"
+    "fmt"
+    "os"
+    "strconv"
+
+    "github.com/tomasbobek/AdventOfCode19/intcode"
+)
+
+func main() {
+    output := flag.String("o", "", "write the graph into this file instead of Standard Output")
+    tracePath := flag.String("trace", "", "merge jumps taken in the run recorded in this JSON Lines trace")
+    run := flag.Bool("run", false, "run the program with given input values and merge the jumps it takes")
+    flag.Parse()
+
+    if flag.NArg() < 1 {
+        fmt.Println("usage: cfg [-o graph.dot] [-trace trace.jsonl] [-run] <program file> [input values...]")
+        os.Exit(2)
+    }
+
+    code, err := intcode.ReadCodeFile(flag.Arg(0))
+    if err != nil {
+        fmt.Println(err)
+        os.Exit(1)
+    }
+
+    graph := intcode.NewControlFlowGraph(code)
+
+    if *tracePath != "" {
+        traceFile, err := os.Open(*tracePath)
+        if err != nil {
+            fmt.Println(err)
+            os.Exit(1)
+        }
+        defer traceFile.Close()
+
+        if err := graph.MergeTrace(traceFile); err != nil {
+            fmt.Println(err)
+            os.Exit(1)
+        }
+    }
+
+    if *run {
+        input := intcode.NewQueueInput()
+        for _, arg := range flag.Args()[1:] {
+            value, err := strconv.ParseInt(arg, 10, 64)
+            if err != nil {
+                fmt.Println(err)
+                os.Exit(1)
+            }
+            input.Push(value)
+        }
+
+        program := &intcode.Program{Quiet: true, Input: input, Output: &intcode.SliceOutput{}, Profile: intcode.NewProfile()}
+        program.LoadCode(code)
+        if err := program.Execute(); err != nil {
+            fmt.Fprintln(os.Stderr, "program stopped:", err)
+        }
+        graph.MergeProfile(program.Profile)
+    }
+
+    out := os.Stdout
+    if *output != "" {
+        out, err = os.Create(*output)
+        if err != nil {
+            fmt.Println(err)
+            os.Exit(1)
+        }
+        defer out.Close()
+    }
+
+    if err := graph.WriteDOT(out); err != nil {
+        fmt.Println(err)
+        os.Exit(1)
+    }
+}
@@ -0,0 +1,69 @@
+// Coverage merges coverage files written by the run command and writes the code annotated with the coverage.
+//
+// Usage: go run ./intcode/cmd/coverage [-o listing.txt] [-merged coverage.json] 7/code coverage.json...
+//
+// Every line of the listing starts with the marker of the address (X executed, R read, W written, . untouched)
+// and the number of executions of the instruction or accesses to the data word.
+package main
+
+import (
+    "flag"
+    "fmt"
+    "os"
+
+    "github.com/tomasbobek/AdventOfCode19/intcode"
+)
+
+func main() {
+    output := flag.String("o", "", "write the listing into this file instead of Standard Output")
+    mergedPath := flag.String("merged", "", "save the merged coverage into this file")
+    flag.Parse()
+
+    if flag.NArg() < 2 {
+        fmt.Println("usage: coverage [-o listing.txt] [-merged coverage.json] <program file> <coverage files...>")
+        os.Exit(2)
+    }
+
+    code, err := intcode.ReadCodeFile(flag.Arg(0))
+    if err != nil {
+        fmt.Println(err)
+        os.Exit(1)
+    }
+
+    coverage := intcode.NewCoverage()
+    for _, file := range flag.Args()[1:] {
+        if _, err := os.Stat(file); err != nil {
+            fmt.Println(err)
+            os.Exit(1)
+        }
+
+        runs, err := intcode.LoadCoverageFromFile(file)
+        if err != nil {
+            fmt.Println(file, ":", err)
+            os.Exit(1)
+        }
+        coverage.Merge(runs)
+    }
+
+    if *mergedPath != "" {
+        if err := coverage.SaveToFile(*mergedPath); err != nil {
+            fmt.Println(err)
+            os.Exit(1)
+        }
+    }
+
+    out := os.Stdout
+    if *output != "" {
+        out, err = os.Create(*output)
+        if err != nil {
+            fmt.Println(err)
+            os.Exit(1)
+        }
+        defer out.Close()
+    }
+
+    if err := coverage.WriteListing(out, code); err != nil {
+        fmt.Println(err)
+        os.Exit(1)
+    }
+}
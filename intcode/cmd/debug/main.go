@@ -0,0 +1,42 @@
+// Debug runs the Intcode program from given file in the interactive step debugger.
+//
+// Usage: go run ./intcode/cmd/debug 11/code [input values...]
+//
+// Given input values are queued for the program before the debugger starts, type "help" for the list of commands.
+package main
+
+import (
+    "fmt"
+    "os"
+    "strconv"
+
+    "github.com/tomasbobek/AdventOfCode19/intcode"
+)
+
+func main() {
+    if len(os.Args) < 2 {
+        fmt.Println("usage: debug <program file> [input values...]")
+        os.Exit(2)
+    }
+
+    code, err := intcode.ReadCodeFile(os.Args[1])
+    if err != nil {
+        fmt.Println(err)
+        os.Exit(1)
+    }
+
+    input := intcode.NewQueueInput()
+    for _, arg := range os.Args[2:] {
+        value, err := strconv.ParseInt(arg, 10, 64)
+        if err != nil {
+            fmt.Println(err)
+            os.Exit(1)
+        }
+        input.Push(value)
+    }
+
+    program := &intcode.Program{Input: input, Output: &intcode.SliceOutput{}}
+    program.LoadCode(code)
+
+    intcode.NewDebugger(program, os.Stdin, os.Stdout).Run()
+}
@@ -0,0 +1,38 @@
+// Disassemble prints the Intcode program from given file as annotated mnemonics.
+//
+// Usage: go run ./intcode/cmd/disassemble [-source] 9/code
+//
+// With -source only the mnemonics are printed, in the format accepted by the assemble command.
+package main
+
+import (
+    "flag"
+    "fmt"
+    "os"
+
+    "github.com/tomasbobek/AdventOfCode19/intcode"
+)
+
+func main() {
+    sourceOnly := flag.Bool("source", false, "print only mnemonics and operands (assembler source)")
+    flag.Parse()
+
+    if flag.NArg() != 1 {
+        fmt.Println("usage: disassemble [-source] <program file>")
+        os.Exit(2)
+    }
+
+    code, err := intcode.ReadCodeFile(flag.Arg(0))
+    if err != nil {
+        fmt.Println(err)
+        os.Exit(1)
+    }
+
+    for _, line := range intcode.Disassemble(code) {
+        if *sourceOnly {
+            fmt.Println(line.Source())
+        } else {
+            fmt.Println(line)
+        }
+    }
+}
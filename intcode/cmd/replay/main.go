@@ -0,0 +1,62 @@
+// Replay runs the Intcode program with the inputs of a recorded session and reports the first step where its
+// outputs diverge from the recorded ones.
+//
+// Usage: go run ./intcode/cmd/replay [-budget cost] [-arithmetic wrap|checked|big] session.jsonl 11/code
+//
+// Sessions are recorded by the run command (-record), the program can be the recorded one or its modified version.
+// The exit status is 1 when the outputs diverge or the program fails.
+package main
+
+import (
+    "flag"
+    "fmt"
+    "os"
+
+    "github.com/tomasbobek/AdventOfCode19/intcode"
+)
+
+func main() {
+    budget := flag.Int64("budget", 0, "stop the program when its instructions cost more than this (0 for no limit)")
+    arithmetic := flag.String("arithmetic", "wrap", "handling of results that do not fit into int64: wrap, checked or big")
+    flag.Parse()
+
+    if flag.NArg() != 2 {
+        fmt.Println("usage: replay [-budget cost] [-arithmetic mode] <session file> <program file>")
+        os.Exit(2)
+    }
+
+    mode, err := intcode.ParseArithmetic(*arithmetic)
+    if err != nil {
+        fmt.Println(err)
+        os.Exit(2)
+    }
+
+    session, err := intcode.LoadSessionFromFile(flag.Arg(0))
+    if err != nil {
+        fmt.Println(err)
+        os.Exit(1)
+    }
+
+    code, err := intcode.ReadCodeFile(flag.Arg(1))
+    if err != nil {
+        fmt.Println(err)
+        os.Exit(1)
+    }
+
+    program := &intcode.Program{Quiet: true, Budget: *budget, Arithmetic: mode}
+    program.LoadCode(code)
+
+    divergence, err := session.Replay(program)
+    if err != nil {
+        fmt.Println("program stopped:", err)
+        os.Exit(1)
+    }
+    if divergence != nil {
+        fmt.Println("outputs diverge at", divergence)
+        os.Exit(1)
+    }
+
+    inputs := len(session.Inputs())
+    fmt.Printf("replayed %d inputs and %d outputs in %d steps, no divergence\n",
+        inputs, len(session.Events) - inputs, program.Steps)
+}
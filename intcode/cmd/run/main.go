@@ -0,0 +1,275 @@
+// Run executes the Intcode program from given file with optional instrumentation.
+//
+// Usage: go run ./intcode/cmd/run [-interactive] [-ascii] [-compiled] [-arithmetic wrap|checked|big]
+//     [-timeout duration] [-trace trace.jsonl] [-profile] [-profile-csv profile.csv] [-resume state.json]
+//     [-checkpoint state.json [-checkpoint-every steps]] [-coverage coverage.json] [-watch from[-to][:r|w|rw]]...
+//     [-audit] [-budget cost] [-costs MUL=3,IN=10] [-record session.jsonl] 9/code [input values...]
+//
+// Given input values are consumed by the program in the order they are listed (after inputs queued in the resumed
+// snapshot), when they run out the program fails unless the input is prompted from Standard Input in interactive mode.
+// In ASCII mode every input argument is a line of text and the output of the program is printed as text.
+// Coverage of the run is merged into the coverage file, so it can collect several runs (see the coverage command).
+// With a budget the program stops once its instructions cost more, every instruction costs 1 unless -costs says
+// otherwise.
+// Recorded session of inputs and outputs can be replayed against the program later (see the replay command).
+// Memory accesses hitting the watchpoints and, with -audit, writes into the loaded code are printed as they happen.
+package main
+
+import (
+    "context"
+    "flag"
+    "fmt"
+    "os"
+    "strconv"
+    "time"
+
+    "github.com/tomasbobek/AdventOfCode19/intcode"
+)
+
+func main() {
+    interactive := flag.Bool("interactive", false, "prompt input from Standard Input when the given values run out")
+    ascii := flag.Bool("ascii", false, "exchange input and output with the program as lines of ASCII text")
+    compiled := flag.Bool("compiled", false, "execute the program translated into Go closures instead of interpreting it")
+    arithmetic := flag.String("arithmetic", "wrap", "handling of results that do not fit into int64: wrap, checked or big")
+    timeout := flag.Duration("timeout", 0, "stop the program when it runs longer than this")
+    tracePath := flag.String("trace", "", "write JSON Lines trace of executed instructions into this file")
+    profile := flag.Bool("profile", false, "print execution hot spots when the program finishes")
+    profileTop := flag.Int("profile-top", 20, "number of hottest addresses in the profile report (0 for all)")
+    profileCSV := flag.String("profile-csv", "", "write execution counts as CSV into this file")
+    resumePath := flag.String("resume", "", "restore the program state from this snapshot file before running")
+    checkpointPath := flag.String("checkpoint", "", "save snapshot of the program state into this file when it stops")
+    checkpointEvery := flag.Int64("checkpoint-every", 0, "save the checkpoint also after every given number of steps")
+    coveragePath := flag.String("coverage", "", "merge addresses used by the program into this coverage file")
+    var watchpoints watchpointList
+    flag.Var(&watchpoints, "watch", "print memory accesses to the address range from[-to][:r|w|rw] (can be repeated)")
+    audit := flag.Bool("audit", false, "print writes into the loaded code")
+    budget := flag.Int64("budget", 0, "stop the program when its instructions cost more than this (0 for no limit)")
+    costs := flag.String("costs", "", "costs of the operations like MUL=3,IN=10, other operations cost 1")
+    recordPath := flag.String("record", "", "record inputs and outputs of the program into this session file")
+    flag.Parse()
+
+    if flag.NArg() < 1 {
+        fmt.Println("usage: run [flags] <program file> [input values...]")
+        flag.PrintDefaults()
+        os.Exit(2)
+    }
+
+    code, err := intcode.ReadCodeFile(flag.Arg(0))
+    if err != nil {
+        fmt.Println(err)
+        os.Exit(1)
+    }
+
+    mode, err := intcode.ParseArithmetic(*arithmetic)
+    if err != nil {
+        fmt.Println(err)
+        os.Exit(2)
+    }
+
+    program := &intcode.Program{Compiled: *compiled, Arithmetic: mode}
+    if mode == intcode.BigArithmetic {
+        // Only outputs written to Standard Output can be of any size
+        output := intcode.NewWriterOutput(os.Stdout)
+        output.Prefix = "Program outputs:  "
+        program.Output = output
+    }
+    program.LoadCode(code)
+
+    // Input is set up before the program is resumed, so that it gets the input pending in the checkpoint
+    var asciiInput *intcode.ASCIIInput
+    var asciiOutput *intcode.ASCIIOutput
+    queue := intcode.NewQueueInput()
+    if *ascii {
+        asciiInput = intcode.NewASCIIInput()
+        if *interactive {
+            asciiInput = intcode.NewASCIIReaderInput(os.Stdin)
+        }
+
+        asciiOutput = intcode.NewASCIIOutput(os.Stdout)
+        asciiInput.Output = asciiOutput
+
+        program.Input = asciiInput
+        program.Output = asciiOutput
+    } else if *interactive {
+        // Values given as arguments are read first, then they are prompted (not captured by the checkpoint)
+        stdin := intcode.NewReaderInput(os.Stdin)
+        stdin.Prompt = "Enter value: "
+        stdin.PromptWriter = os.Stdout
+        program.Input = intcode.InputFunc(func(ctx context.Context) (int64, error) {
+            if queue.Len() > 0 {
+                return queue.ReadInput(ctx)
+            }
+            return stdin.ReadInput(ctx)
+        })
+    } else {
+        program.Input = queue
+    }
+
+    if *resumePath != "" {
+        snapshot, err := intcode.LoadSnapshotFromFile(*resumePath)
+        if err != nil {
+            fmt.Println(err)
+            os.Exit(1)
+        }
+        program.Restore(snapshot)
+        // Program halted by exhausted budget continues with the new one
+        program.Halt = false
+    }
+
+    if asciiInput != nil {
+        asciiInput.PushLine(flag.Args()[1:]...)
+    } else {
+        for _, arg := range flag.Args()[1:] {
+            value, err := strconv.ParseInt(arg, 10, 64)
+            if err != nil {
+                fmt.Println(err)
+                os.Exit(1)
+            }
+            queue.Push(value)
+        }
+    }
+
+    if *tracePath != "" {
+        traceFile, err := os.Create(*tracePath)
+        if err != nil {
+            fmt.Println(err)
+            os.Exit(1)
+        }
+        defer traceFile.Close()
+
+        program.Trace = traceFile
+    }
+
+    if *profile || *profileCSV != "" {
+        program.Profile = intcode.NewProfile()
+    }
+
+    if *coveragePath != "" {
+        program.Coverage, err = intcode.LoadCoverageFromFile(*coveragePath)
+        if err != nil {
+            fmt.Println(err)
+            os.Exit(1)
+        }
+    }
+
+    if len(watchpoints) > 0 || *audit {
+        program.Watcher = &intcode.Watcher{Watchpoints: watchpoints, AuditCode: *audit, Log: os.Stdout}
+    }
+
+    if *recordPath != "" {
+        program.Session = &intcode.Session{}
+    }
+
+    program.Budget = *budget
+    if *costs != "" {
+        program.Costs, err = intcode.ParseCosts(*costs)
+        if err != nil {
+            fmt.Println(err)
+            os.Exit(2)
+        }
+    }
+
+    ctx := context.Background()
+    if *timeout > 0 {
+        var cancel context.CancelFunc
+        ctx, cancel = context.WithTimeout(ctx, *timeout)
+        defer cancel()
+    }
+
+    if *checkpointPath != "" && *checkpointEvery > 0 {
+        start := time.Now()
+        for !program.Completed && !program.Halt && ctx.Err() == nil {
+            err = program.Step()
+            if program.Steps % *checkpointEvery == 0 {
+                saveCheckpoint(program, *checkpointPath)
+            }
+        }
+        if program.Profile != nil {
+            program.Profile.Duration += time.Since(start)
+        }
+    } else {
+        err = program.ExecuteContext(ctx)
+    }
+
+    if asciiOutput != nil && asciiOutput.Pending() != "" {
+        asciiOutput.Flush()
+        fmt.Println()
+    }
+
+    if err != nil {
+        fmt.Println("program stopped:", err)
+    }
+
+    if *checkpointPath != "" {
+        saveCheckpoint(program, *checkpointPath)
+    }
+
+    fmt.Println("memory:", program.MemoryStats())
+
+    if *audit {
+        fmt.Println("code writes:", len(program.Watcher.CodeWrites))
+    }
+
+    if *recordPath != "" {
+        if err := program.Session.SaveToFile(*recordPath); err != nil {
+            fmt.Println(err)
+            os.Exit(1)
+        }
+    }
+
+    if *budget > 0 {
+        fmt.Printf("budget: %d of %d used\n", program.BudgetUsed, *budget)
+    } else if *costs != "" {
+        fmt.Println("cost:", program.BudgetUsed)
+    }
+
+    if *coveragePath != "" {
+        fmt.Println("coverage:", program.Coverage.Summary(code))
+        if err := program.Coverage.SaveToFile(*coveragePath); err != nil {
+            fmt.Println(err)
+            os.Exit(1)
+        }
+    }
+
+    if *profile {
+        fmt.Println()
+        program.Profile.WriteReport(os.Stdout, *profileTop)
+    }
+
+    if *profileCSV != "" {
+        csvFile, err := os.Create(*profileCSV)
+        if err != nil {
+            fmt.Println(err)
+            os.Exit(1)
+        }
+        defer csvFile.Close()
+
+        if err := program.Profile.WriteCSV(csvFile); err != nil {
+            fmt.Println(err)
+            os.Exit(1)
+        }
+    }
+}
+
+func saveCheckpoint(program *intcode.Program, file string) {
+    if err := program.Snapshot().SaveToFile(file); err != nil {
+        fmt.Println(err)
+        os.Exit(1)
+    }
+}
+
+// Values of repeated -watch flag
+type watchpointList []intcode.Watchpoint
+
+func (l *watchpointList) String() string {
+    return fmt.Sprint(*l)
+}
+
+func (l *watchpointList) Set(value string) error {
+    watchpoint, err := intcode.ParseWatchpoint(value)
+    if err != nil {
+        return err
+    }
+    *l = append(*l, watchpoint)
+    return nil
+}
@@ -0,0 +1,58 @@
+// Transpile generates Go source implementing the Intcode program, which can be built into a native binary.
+//
+// Usage: go run ./intcode/cmd/transpile [-o program.go] [-package main] 9/code
+//
+// The generated file uses the intcode package, so it has to be built within this module, for example:
+//     go run ./intcode/cmd/transpile -o native/day9/main.go 9/code && go build -o day9 ./native/day9
+// Instructions that write into the translated code are reported, the program falls back to the interpreter
+// when it executes them.
+package main
+
+import (
+    "bytes"
+    "flag"
+    "fmt"
+    "io/ioutil"
+    "os"
+
+    "github.com/tomasbobek/AdventOfCode19/intcode"
+)
+
+func main() {
+    output := flag.String("o", "", "write the source into this file instead of Standard Output")
+    packageName := flag.String("package", "main", "package of the generated source, main package gets main function")
+    flag.Parse()
+
+    if flag.NArg() != 1 {
+        fmt.Println("usage: transpile [-o program.go] [-package name] <program file>")
+        os.Exit(2)
+    }
+
+    code, err := intcode.ReadCodeFile(flag.Arg(0))
+    if err != nil {
+        fmt.Println(err)
+        os.Exit(1)
+    }
+
+    var source bytes.Buffer
+    codeWrites, err := intcode.Transpile(code, &source, intcode.TranspileOptions{Package: *packageName, Source: flag.Arg(0)})
+    if err != nil {
+        fmt.Println(err)
+        os.Exit(1)
+    }
+
+    for _, write := range codeWrites {
+        fmt.Fprintf(os.Stderr, "warning: instruction at address %d writes into translated code at address %d\n",
+            write.Address, write.Target)
+    }
+
+    if *output == "" {
+        fmt.Print(source.String())
+        return
+    }
+
+    if err := ioutil.WriteFile(*output, source.Bytes(), 0644); err != nil {
+        fmt.Println(err)
+        os.Exit(1)
+    }
+}
@@ -0,0 +1,247 @@
+package intcode
+
+import "context"
+
+// Threaded code of the program, every address of the memory that holds a valid instruction is translated into
+// a closure which executes it and returns the closure of the next instruction. Jump targets given in immediate mode
+// are linked directly, other jumps look the target up when they are executed.
+type threadedCode struct {
+    ops []*compiledOp
+}
+
+type compiledOp struct {
+    exec   func(p *Program) *compiledOp
+    length int
+    // Set when the program writes into the words of the instruction, it is then executed by the interpreter
+    stale  bool
+}
+
+// Reads a parameter value or computes an address of the written parameter
+type operandFunc func(p *Program) int64
+
+// Translates the dense memory of the program into threaded code. Input, output and terminate instructions are not
+// translated, they are left to the interpreter like the instructions that are modified by the program later.
+func compile(memory []int64) *threadedCode {
+    code := &threadedCode{ops: make([]*compiledOp, len(memory))}
+
+    // Closures are linked to each other, so all of them have to exist before they are built
+    instructions := make([]Instruction, len(memory))
+    for address := range memory {
+        instruction, ok := DecodeInstruction(memory, address)
+        if !ok || !isCompiled(instruction.Operation) {
+            continue
+        }
+        instructions[address] = instruction
+        code.ops[address] = &compiledOp{length: instruction.Length}
+    }
+
+    for address, op := range code.ops {
+        if op != nil {
+            op.exec = code.build(address, &instructions[address])
+        }
+    }
+
+    return code
+}
+
+func isCompiled(operation InstructionOperation) bool {
+    switch operation {
+    case Read, Write, Terminate:
+        return false
+    default:
+        return true
+    }
+}
+
+// Returns the translated instruction on the address, nil when it has to be interpreted
+func (c *threadedCode) at(address int) *compiledOp {
+    if address < 0 || address >= len(c.ops) || c.ops[address] == nil || c.ops[address].stale {
+        return nil
+    }
+    return c.ops[address]
+}
+
+// Marks all the translated instructions containing the address as stale
+func (c *threadedCode) invalidate(address int64) {
+    for start := address - maxParams; start <= address; start++ {
+        if start >= 0 && start < int64(len(c.ops)) && c.ops[start] != nil && start + int64(c.ops[start].length) > address {
+            c.ops[start].stale = true
+        }
+    }
+}
+
+func (c *threadedCode) build(address int, i *Instruction) func(p *Program) *compiledOp {
+    next := address + i.Length
+    nextOp := c.lookup(next)
+
+    var operands [maxParams]operandFunc
+    for j := range i.Params {
+        if j < i.getValuesCount() {
+            operands[j] = valueOperand(i.Params[j])
+        } else {
+            operands[j] = addressOperand(i.Params[j])
+        }
+    }
+    a, b, target := operands[0], operands[1], operands[2]
+
+    switch i.Operation {
+    case Add:
+        return func(p *Program) *compiledOp {
+            x, y := a(p), b(p)
+            if p.fault != nil {
+                return nil
+            }
+            p.store(target(p), x + y)
+            return p.continueAt(next, nextOp)
+        }
+    case Multiply:
+        return func(p *Program) *compiledOp {
+            x, y := a(p), b(p)
+            if p.fault != nil {
+                return nil
+            }
+            p.store(target(p), x * y)
+            return p.continueAt(next, nextOp)
+        }
+    case LessThan:
+        return func(p *Program) *compiledOp {
+            x, y := a(p), b(p)
+            if p.fault != nil {
+                return nil
+            }
+            p.store(target(p), boolValue(x < y))
+            return p.continueAt(next, nextOp)
+        }
+    case Equals:
+        return func(p *Program) *compiledOp {
+            x, y := a(p), b(p)
+            if p.fault != nil {
+                return nil
+            }
+            p.store(target(p), boolValue(x == y))
+            return p.continueAt(next, nextOp)
+        }
+    case JumpIfTrue, JumpIfFalse:
+        jumpIf := i.Operation == JumpIfTrue
+        jump := c.jump(i.Params[1])
+        return func(p *Program) *compiledOp {
+            x, y := a(p), b(p)
+            if p.fault != nil {
+                return nil
+            }
+            if (x != 0) == jumpIf {
+                return jump(p, int(y))
+            }
+            return p.continueAt(next, nextOp)
+        }
+    case SetRelativeBase:
+        return func(p *Program) *compiledOp {
+            x := a(p)
+            if p.fault != nil {
+                return nil
+            }
+            p.RelativeBase += int(x)
+            return p.continueAt(next, nextOp)
+        }
+    default:
+        return nil
+    }
+}
+
+// Returns the function performing the jump, the target closure is linked directly when it is known in advance
+func (c *threadedCode) jump(param InstructionParam) func(p *Program, address int) *compiledOp {
+    if param.Mode == ImmediateMode {
+        op := c.lookup(int(param.Value))
+        return func(p *Program, address int) *compiledOp {
+            return p.continueAt(address, op)
+        }
+    }
+
+    return func(p *Program, address int) *compiledOp {
+        return p.continueAt(address, p.compiled.at(address))
+    }
+}
+
+func (c *threadedCode) lookup(address int) *compiledOp {
+    if address < 0 || address >= len(c.ops) {
+        return nil
+    }
+    return c.ops[address]
+}
+
+func valueOperand(param InstructionParam) operandFunc {
+    value := param.Value
+    switch param.Mode {
+    case ImmediateMode:
+        return func(p *Program) int64 { return value }
+    case RelativeMode:
+        return func(p *Program) int64 { return p.read(int64(p.RelativeBase) + value) }
+    default:
+        return func(p *Program) int64 { return p.read(value) }
+    }
+}
+
+func addressOperand(param InstructionParam) operandFunc {
+    value := param.Value
+    if param.Mode == RelativeMode {
+        return func(p *Program) int64 { return int64(p.RelativeBase) + value }
+    }
+    return func(p *Program) int64 { return value }
+}
+
+func boolValue(value bool) int64 {
+    if value {
+        return 1
+    }
+    return 0
+}
+
+// Moves the program to the address and returns the closure that continues there, nil when the program failed
+// (on writing the result) or the instruction on the address has to be interpreted.
+func (p *Program) continueAt(address int, op *compiledOp) *compiledOp {
+    p.Position = address
+    if int64(address) > p.highestAddress {
+        p.highestAddress = int64(address)
+    }
+    if p.fault != nil || op == nil || op.stale {
+        return nil
+    }
+    return op
+}
+
+// Runs the program like ExecuteContext, but the instructions are executed as threaded code (see compile). The code
+// is translated on the first execution after the code was loaded or restored.
+func (p *Program) executeCompiled(ctx context.Context) error {
+    if p.compiled == nil {
+        p.compiled = compile(p.Memory)
+    }
+
+    for !p.Completed && !p.Halt {
+        op := p.compiled.at(p.Position)
+        if op == nil {
+            if p.Steps % 1024 == 0 && ctx.Err() != nil {
+                return p.fail(ctx.Err())
+            }
+            if err := p.Step(); err != nil {
+                return err
+            }
+            continue
+        }
+
+        p.fault = nil
+        for op != nil {
+            p.Steps++
+            op = op.exec(p)
+
+            if p.Steps % 1024 == 0 && ctx.Err() != nil {
+                return p.fail(ctx.Err())
+            }
+        }
+
+        if p.fault != nil {
+            return p.fault
+        }
+    }
+
+    return nil
+}
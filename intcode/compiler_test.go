@@ -0,0 +1,104 @@
+package intcode
+
+import (
+    "context"
+    "reflect"
+    "testing"
+)
+
+// Compiled programs have to produce the same outputs and leave the same memory as the interpreted ones, including
+// the programs that modify their own code (day 5)
+func TestCompiledMatchesInterpreted(t *testing.T) {
+    tests := []struct {
+        name   string
+        file   string
+        // Words written into the code before it is executed, by their address
+        patch  map[int]int64
+        inputs []int64
+        // Painting robot (day 11) drives the program instead of the inputs, starting on the tile of the color
+        robot  bool
+        color  int64
+    }{
+        {name: "day 2", file: "../2/code", patch: map[int]int64{1: 12, 2: 2}},
+        {name: "day 5 air conditioner", file: "../5/code", inputs: []int64{1}},
+        {name: "day 5 thermal radiator", file: "../5/code", inputs: []int64{5}},
+        {name: "day 7 amplifier", file: "../7/code", inputs: []int64{4, 0}},
+        {name: "day 7 amplifier with signal", file: "../7/code", inputs: []int64{2, 1234}},
+        {name: "day 9 test mode", file: "../9/code", inputs: []int64{1}},
+        {name: "day 9 sensor boost", file: "../9/code", inputs: []int64{2}},
+        {name: "day 11 robot on black tile", file: "../11/code", robot: true},
+        {name: "day 11 robot on white tile", file: "../11/code", robot: true, color: 1},
+    }
+
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            code, err := ReadCodeFile(test.file)
+            if err != nil {
+                t.Fatal(err)
+            }
+            for address, value := range test.patch {
+                code[address] = value
+            }
+
+            run := func(compiled bool) *Program {
+                program := &Program{Quiet: true, Input: NewQueueInput(test.inputs...), Output: &SliceOutput{}, Compiled: compiled}
+                if test.robot {
+                    robot := newTestRobot(test.color)
+                    program.Input, program.Output = InputFunc(robot.scan), robot
+                }
+                program.LoadCode(code)
+                if err := program.Execute(); err != nil {
+                    t.Fatalf("compiled %v: %v", compiled, err)
+                }
+                return program
+            }
+
+            interpreted, compiled := run(false), run(true)
+            if compiled.compiled == nil {
+                t.Fatal("program was interpreted")
+            }
+            if !reflect.DeepEqual(interpreted.Output, compiled.Output) {
+                t.Errorf("outputs differ: interpreted %v, compiled %v", interpreted.Output, compiled.Output)
+            }
+            if !reflect.DeepEqual(interpreted.Memory, compiled.Memory) {
+                t.Error("memory differs")
+            }
+            if interpreted.Steps != compiled.Steps {
+                t.Errorf("steps differ: interpreted %d, compiled %d", interpreted.Steps, compiled.Steps)
+            }
+        })
+    }
+}
+
+// Painting robot of day 11, the outputs of the program alternate between the color to paint and the turn to make
+type testRobot struct {
+    Outputs []int64
+    Hull    map[[2]int]int64
+
+    position  [2]int
+    direction int
+}
+
+func newTestRobot(start int64) *testRobot {
+    return &testRobot{Hull: map[[2]int]int64{{0, 0}: start}}
+}
+
+// Color of the tile under the robot
+func (r *testRobot) scan(ctx context.Context) (int64, error) {
+    return r.Hull[r.position], nil
+}
+
+func (r *testRobot) WriteOutput(ctx context.Context, value int64) error {
+    r.Outputs = append(r.Outputs, value)
+    if len(r.Outputs) % 2 == 1 {
+        r.Hull[r.position] = value
+        return nil
+    }
+
+    // Turns left on 0 and right on 1, directions are up, right, down and left
+    r.direction = (r.direction + 4 + 2 * int(value) - 1) % 4
+    moves := [][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+    r.position[0] += moves[r.direction][0]
+    r.position[1] += moves[r.direction][1]
+    return nil
+}
@@ -0,0 +1,220 @@
+package intcode
+
+import (
+    "encoding/json"
+    "fmt"
+    "io"
+    "io/ioutil"
+    "os"
+    "sort"
+    "strings"
+)
+
+// CoverageFlags describe how an address was used by the program
+type CoverageFlags int
+
+const (
+    // The word was part of an executed instruction
+    CoverageExecuted CoverageFlags = 1 << iota
+    // The word was read as a parameter value
+    CoverageRead
+    // The word was written by an instruction
+    CoverageWritten
+)
+
+// Marker of the flags in the listing, like X for executed code or RW for data both read and written ("." when
+// the address was never touched)
+func (f CoverageFlags) String() string {
+    if f == 0 {
+        return "."
+    }
+
+    var marker strings.Builder
+    if f & CoverageExecuted != 0 {
+        marker.WriteString("X")
+    }
+    if f & CoverageRead != 0 {
+        marker.WriteString("R")
+    }
+    if f & CoverageWritten != 0 {
+        marker.WriteString("W")
+    }
+    return marker.String()
+}
+
+// Coverage collects the addresses used by a Program, it is filled while it is assigned to Program.Coverage.
+// The same Coverage can be assigned to several programs one after another (or coverages of separate runs merged
+// by Merge), but not to programs running concurrently.
+type Coverage struct {
+    // Executions of the instructions by the address of their operation code
+    Instructions map[int64]int64 `json:"instructions"`
+    // Words of executed instructions, including their parameters
+    Executed     map[int64]int64 `json:"executed"`
+    Read         map[int64]int64 `json:"read"`
+    Written      map[int64]int64 `json:"written"`
+    // Number of completed runs
+    Runs         int             `json:"runs"`
+}
+
+func NewCoverage() *Coverage {
+    return &Coverage{
+        Instructions: map[int64]int64{},
+        Executed:     map[int64]int64{},
+        Read:         map[int64]int64{},
+        Written:      map[int64]int64{},
+    }
+}
+
+func (c *Coverage) recordInstruction(address int, length int) {
+    c.Instructions[int64(address)]++
+    for k := address; k < address + length; k++ {
+        c.Executed[int64(k)]++
+    }
+}
+
+// Flags returns how the address was used in all the recorded runs
+func (c *Coverage) Flags(address int64) CoverageFlags {
+    var flags CoverageFlags
+    if c.Executed[address] > 0 {
+        flags |= CoverageExecuted
+    }
+    if c.Read[address] > 0 {
+        flags |= CoverageRead
+    }
+    if c.Written[address] > 0 {
+        flags |= CoverageWritten
+    }
+    return flags
+}
+
+// Merge adds the coverage of other runs
+func (c *Coverage) Merge(other *Coverage) {
+    mergeCounts(c.Instructions, other.Instructions)
+    mergeCounts(c.Executed, other.Executed)
+    mergeCounts(c.Read, other.Read)
+    mergeCounts(c.Written, other.Written)
+    c.Runs += other.Runs
+}
+
+func mergeCounts(counts map[int64]int64, other map[int64]int64) {
+    for address, count := range other {
+        counts[address] += count
+    }
+}
+
+// CoverageSummary counts the words of the code by their use, data are words that were read or written but not
+// executed
+type CoverageSummary struct {
+    Words     int
+    Executed  int
+    Data      int
+    Untouched int
+}
+
+func (s CoverageSummary) String() string {
+    return fmt.Sprintf("%d words: %d executed, %d data, %d untouched", s.Words, s.Executed, s.Data, s.Untouched)
+}
+
+// Summary classifies the words of the loaded code
+func (c *Coverage) Summary(code []int64) CoverageSummary {
+    summary := CoverageSummary{Words: len(code)}
+    for address := range code {
+        flags := c.Flags(int64(address))
+        switch {
+        case flags & CoverageExecuted != 0:
+            summary.Executed++
+        case flags != 0:
+            summary.Data++
+        default:
+            summary.Untouched++
+        }
+    }
+    return summary
+}
+
+// WriteListing writes the code annotated with the coverage markers (see CoverageFlags) and execution counts.
+// Executed instructions are decoded from the code, other words are listed one by one as DATA. Addresses beyond
+// the code are listed only when the program has touched them.
+func (c *Coverage) WriteListing(w io.Writer, code []int64) error {
+    if _, err := fmt.Fprintf(w, "coverage of %d runs, %s\n", c.Runs, c.Summary(code)); err != nil {
+        return err
+    }
+
+    for address := 0; address < len(code); {
+        line := DisassembledLine{Address: address, Words: code[address:address+1], Mnemonic: DataMnemonic, Operands: []string{fmt.Sprint(code[address])}}
+        count := c.Read[int64(address)] + c.Written[int64(address)]
+
+        if executions := c.Instructions[int64(address)]; executions > 0 {
+            if instruction, ok := DecodeInstruction(code, address); ok {
+                line = disassembleInstruction(code, address, instruction)
+            }
+            count = executions
+        }
+
+        if _, err := fmt.Fprintf(w, "%-3s %10s %s\n", c.Flags(int64(address)), formatCount(count), line); err != nil {
+            return err
+        }
+        address += len(line.Words)
+    }
+
+    for _, address := range c.touchedBeyond(int64(len(code))) {
+        count := c.Read[address] + c.Written[address] + c.Instructions[address]
+        if _, err := fmt.Fprintf(w, "%-3s %10s %5d\n", c.Flags(address), formatCount(count), address); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// Returns sorted touched addresses from given one
+func (c *Coverage) touchedBeyond(start int64) []int64 {
+    touched := map[int64]bool{}
+    for _, counts := range []map[int64]int64{c.Executed, c.Read, c.Written} {
+        for address := range counts {
+            if address >= start {
+                touched[address] = true
+            }
+        }
+    }
+
+    addresses := make([]int64, 0, len(touched))
+    for address := range touched {
+        addresses = append(addresses, address)
+    }
+    sort.Slice(addresses, func(a, b int) bool { return addresses[a] < addresses[b] })
+    return addresses
+}
+
+func formatCount(count int64) string {
+    if count == 0 {
+        return ""
+    }
+    return fmt.Sprint(count)
+}
+
+func (c *Coverage) SaveToFile(file string) error {
+    data, err := json.Marshal(c)
+    if err != nil {
+        return err
+    }
+
+    return ioutil.WriteFile(file, data, 0644)
+}
+
+// LoadCoverageFromFile reads coverage saved by SaveToFile, missing file gives empty coverage so runs can be merged
+// into a file that does not exist yet.
+func LoadCoverageFromFile(file string) (*Coverage, error) {
+    data, err := ioutil.ReadFile(file)
+    if os.IsNotExist(err) {
+        return NewCoverage(), nil
+    } else if err != nil {
+        return nil, err
+    }
+
+    coverage := NewCoverage()
+    if err := json.Unmarshal(data, coverage); err != nil {
+        return nil, err
+    }
+    return coverage, nil
+}
@@ -0,0 +1,431 @@
+package intcode
+
+import (
+    "bufio"
+    "fmt"
+    "io"
+    "sort"
+    "strconv"
+    "strings"
+)
+
+const debuggerHelp = `commands:
+  s, step [n]              execute next n instructions (default 1)
+  c, continue              run until breakpoint, watchpoint, halt or end of the program
+  sb, back [n]             step back n instructions (default 1)
+  bw, backwrite <addr>     step back to the instruction that has written the address last
+  rewind <step>            step back to the state before given step (numbered from 1) was executed
+  b, break <addr|op>       set breakpoint on address or operation (mnemonic like OUT or ARB)
+  d, delete <addr|op>      remove breakpoint
+  bl, breakpoints          list breakpoints
+  w, watch <range>         watch reads and/or writes of range from[-to][:r|w|rw] (default rw)
+  uw, unwatch <range>      remove watchpoints on the address range
+  wl, watchpoints          list watchpoints
+  audit                    list writes into the loaded code
+  r, regs                  show position, relative base and state flags
+  l, list [addr] [n]       disassemble n instructions from address (default current position)
+  x, mem <addr> [n]        dump n memory words from address (default 16)
+  set <addr> <value>...    patch memory starting at address
+  in <value>...            queue input values for the program
+  io                       show pending input and produced output
+  q, quit                  leave the debugger
+`
+
+// Number of the most recent steps the debugger can step back
+const debuggerJournalLimit = 1000000
+
+// Debugger drives a Program step by step from a line oriented prompt. The program has to receive its inputs
+// through QueueInput or PushInput (see "in" command), execution stops before every read that would find them empty
+// (or the queue of ASCIIInput without reader).
+// Values written to SliceOutput are echoed as they are produced. Memory accesses are observed by a Watcher
+// auditing the writes into the loaded code, its watchpoints and code writes are reported after every step.
+// Steps are recorded into a Journal, so the program can be stepped back.
+type Debugger struct {
+    Program *Program
+
+    addressBreakpoints map[int]bool
+    opCodeBreakpoints  map[InstructionOperation]bool
+
+    in  *bufio.Scanner
+    out io.Writer
+}
+
+func NewDebugger(program *Program, in io.Reader, out io.Writer) *Debugger {
+    if program.Watcher == nil {
+        program.Watcher = &Watcher{AuditCode: true}
+    }
+    if program.Journal == nil {
+        program.Journal = NewJournal(debuggerJournalLimit)
+    }
+
+    return &Debugger{
+        Program:            program,
+        addressBreakpoints: map[int]bool{},
+        opCodeBreakpoints:  map[InstructionOperation]bool{},
+        in:                 bufio.NewScanner(in),
+        out:                out,
+    }
+}
+
+// Run reads and executes commands until the input is exhausted or "quit" is entered
+func (d *Debugger) Run() {
+    d.printCurrent()
+
+    for {
+        fmt.Fprint(d.out, "(intcode) ")
+        if !d.in.Scan() {
+            fmt.Fprintln(d.out)
+            return
+        }
+
+        fields := strings.Fields(d.in.Text())
+        if len(fields) == 0 {
+            continue
+        }
+
+        if fields[0] == "q" || fields[0] == "quit" {
+            return
+        }
+
+        if err := d.execute(fields[0], fields[1:]); err != nil {
+            fmt.Fprintln(d.out, "error:", err)
+        }
+    }
+}
+
+func (d *Debugger) execute(command string, args []string) error {
+    switch command {
+    case "s", "step":
+        count := 1
+        if len(args) > 0 {
+            n, err := strconv.Atoi(args[0])
+            if err != nil {
+                return err
+            }
+            count = n
+        }
+        for k := 0; k < count; k++ {
+            if !d.step() {
+                break
+            }
+        }
+        d.printCurrent()
+    case "c", "continue":
+        for {
+            hits := len(d.Program.Watcher.Hits)
+            if !d.step() {
+                break
+            }
+            if len(d.Program.Watcher.Hits) > hits {
+                fmt.Fprintln(d.out, "watchpoint reached")
+                break
+            }
+            if d.isAtBreakpoint() {
+                fmt.Fprintln(d.out, "breakpoint reached")
+                break
+            }
+        }
+        d.printCurrent()
+    case "sb", "back":
+        count := 1
+        if err := parseOptionalInts(args, &count); err != nil {
+            return err
+        }
+        for k := 0; k < count; k++ {
+            if err := d.Program.StepBack(); err != nil {
+                fmt.Fprintln(d.out, err)
+                break
+            }
+        }
+        d.printCurrent()
+    case "bw", "backwrite":
+        var address int
+        if len(args) != 1 {
+            return fmt.Errorf("usage: backwrite <addr>")
+        }
+        if err := parseOptionalInts(args, &address); err != nil {
+            return err
+        }
+        if err := d.Program.StepBackToWrite(int64(address)); err != nil {
+            return err
+        }
+        d.printCurrent()
+    case "rewind":
+        var step int
+        if len(args) != 1 {
+            return fmt.Errorf("usage: rewind <step>")
+        }
+        if err := parseOptionalInts(args, &step); err != nil {
+            return err
+        }
+        if err := d.Program.Rewind(int64(step)); err != nil {
+            return err
+        }
+        d.printCurrent()
+    case "b", "break":
+        return d.setBreakpoint(args, true)
+    case "d", "delete":
+        return d.setBreakpoint(args, false)
+    case "bl", "breakpoints":
+        d.printBreakpoints()
+    case "w", "watch":
+        if len(args) != 1 {
+            return fmt.Errorf("usage: watch <from[-to][:r|w|rw]>")
+        }
+        watchpoint, err := ParseWatchpoint(args[0])
+        if err != nil {
+            return err
+        }
+        d.Program.Watcher.Watch(watchpoint.From, watchpoint.To, watchpoint.Access)
+    case "uw", "unwatch":
+        if len(args) != 1 {
+            return fmt.Errorf("usage: unwatch <from[-to]>")
+        }
+        watchpoint, err := ParseWatchpoint(args[0])
+        if err != nil {
+            return err
+        }
+        if !d.Program.Watcher.Unwatch(watchpoint.From, watchpoint.To) {
+            return fmt.Errorf("no watchpoint on %s", args[0])
+        }
+    case "wl", "watchpoints":
+        fmt.Fprintln(d.out, "watchpoints:", d.Program.Watcher.Watchpoints)
+    case "audit":
+        for _, write := range d.Program.Watcher.CodeWrites {
+            fmt.Fprintln(d.out, write)
+        }
+        fmt.Fprintln(d.out, len(d.Program.Watcher.CodeWrites), "writes into the loaded code")
+    case "r", "regs":
+        fmt.Fprintf(d.out, "position: %d  relativeBase: %d  completed: %t  halt: %t\n",
+            d.Program.Position, d.Program.RelativeBase, d.Program.Completed, d.Program.Halt)
+        fmt.Fprintf(d.out, "executed steps: %d  journal: %d steps back\n", d.Program.Steps, d.Program.Journal.Len())
+        fmt.Fprintln(d.out, "memory:", d.Program.MemoryStats())
+    case "l", "list":
+        address, count := d.Program.Position, 10
+        if err := parseOptionalInts(args, &address, &count); err != nil {
+            return err
+        }
+        d.printListing(address, count)
+    case "x", "mem":
+        address, count := d.Program.Position, 16
+        if err := parseOptionalInts(args, &address, &count); err != nil {
+            return err
+        }
+        d.printMemory(address, count)
+    case "set":
+        if len(args) < 2 {
+            return fmt.Errorf("usage: set <addr> <value>...")
+        }
+        address, err := strconv.Atoi(args[0])
+        if err != nil {
+            return err
+        }
+        for k, arg := range args[1:] {
+            value, err := strconv.ParseInt(arg, 10, 64)
+            if err != nil {
+                return err
+            }
+            if err := d.Program.WriteMemory(int64(address + k), value); err != nil {
+                return err
+            }
+        }
+    case "in":
+        for _, arg := range args {
+            value, err := strconv.ParseInt(arg, 10, 64)
+            if err != nil {
+                return err
+            }
+            switch input := d.Program.Input.(type) {
+            case nil:
+                d.Program.PushInput(value)
+            case *QueueInput:
+                input.Push(value)
+            default:
+                return fmt.Errorf("input of the program can not queue values")
+            }
+        }
+    case "io":
+        if d.Program.Journal.replaying() {
+            fmt.Fprintln(d.out, "inputs of undone steps (read first):", d.Program.Journal.Replay())
+        }
+        if queue := d.Program.queue(); queue != nil {
+            fmt.Fprintln(d.out, "pending input:", queue.Pending())
+        }
+        if output, ok := d.Program.Output.(*SliceOutput); ok {
+            fmt.Fprintln(d.out, "output:", output.Values)
+        }
+    case "h", "help":
+        fmt.Fprint(d.out, debuggerHelp)
+    default:
+        return fmt.Errorf("unknown command %q, type help for the list of commands", command)
+    }
+
+    return nil
+}
+
+// Executes single instruction unless the program has already finished or waits for input that is not available
+func (d *Debugger) step() bool {
+    p := d.Program
+    if p.Completed {
+        return false
+    }
+
+    instruction, ok := DecodeInstruction(p.Memory, p.Position)
+    if ok && instruction.Operation == Read && !d.isInputAvailable() {
+        fmt.Fprintln(d.out, "program waits for input, provide it with: in <value>...")
+        return false
+    }
+
+    output, echoOutput := p.Output.(*SliceOutput)
+    outputCount := 0
+    if echoOutput {
+        outputCount = len(output.Values)
+    }
+
+    hits, codeWrites := len(p.Watcher.Hits), len(p.Watcher.CodeWrites)
+
+    p.Halt = false
+    err := p.Step()
+
+    if echoOutput && len(output.Values) > outputCount {
+        fmt.Fprintln(d.out, "program outputs:", output.Values[outputCount:])
+    }
+    for _, hit := range p.Watcher.Hits[hits:] {
+        fmt.Fprintln(d.out, "watchpoint:", hit)
+    }
+    for _, write := range p.Watcher.CodeWrites[codeWrites:] {
+        fmt.Fprintln(d.out, "code write:", write)
+    }
+
+    if err != nil {
+        fmt.Fprintln(d.out, "program stopped:", err)
+        return false
+    }
+
+    return true
+}
+
+func (d *Debugger) isInputAvailable() bool {
+    p := d.Program
+    if p.Journal.replaying() {
+        return true
+    }
+
+    // ASCIIInput reads the next line from its reader when the queued characters run out
+    if input, ok := p.Input.(*ASCIIInput); ok && input.reader != nil {
+        return true
+    }
+    if queue := p.queue(); queue != nil {
+        return queue.Len() > 0
+    }
+
+    // Other inputs can not tell in advance, they are trusted to provide the value
+    return true
+}
+
+func (d *Debugger) isAtBreakpoint() bool {
+    if d.addressBreakpoints[d.Program.Position] {
+        return true
+    }
+
+    instruction, ok := DecodeInstruction(d.Program.Memory, d.Program.Position)
+    return ok && d.opCodeBreakpoints[instruction.Operation]
+}
+
+func (d *Debugger) setBreakpoint(args []string, enabled bool) error {
+    if len(args) != 1 {
+        return fmt.Errorf("breakpoint needs single address or operation")
+    }
+
+    if address, err := strconv.Atoi(args[0]); err == nil {
+        if enabled {
+            d.addressBreakpoints[address] = true
+        } else {
+            delete(d.addressBreakpoints, address)
+        }
+        return nil
+    }
+
+    operation, ok := operationNames[strings.ToLower(args[0])]
+    if !ok {
+        return fmt.Errorf("unknown operation %q", args[0])
+    }
+    if enabled {
+        d.opCodeBreakpoints[operation] = true
+    } else {
+        delete(d.opCodeBreakpoints, operation)
+    }
+
+    return nil
+}
+
+func (d *Debugger) printBreakpoints() {
+    var addresses []int
+    for address := range d.addressBreakpoints {
+        addresses = append(addresses, address)
+    }
+    sort.Ints(addresses)
+
+    var operations []string
+    for operation := range d.opCodeBreakpoints {
+        operations = append(operations, Mnemonics[operation])
+    }
+    sort.Strings(operations)
+
+    fmt.Fprintln(d.out, "addresses:", addresses)
+    fmt.Fprintln(d.out, "operations:", operations)
+}
+
+func (d *Debugger) printCurrent() {
+    if d.Program.Completed {
+        fmt.Fprintln(d.out, "program has completed")
+        return
+    }
+    d.printListing(d.Program.Position, 1)
+}
+
+func (d *Debugger) printListing(address int, count int) {
+    memory := d.Program.Memory
+    for k := 0; k < count && address >= 0 && address < len(memory); k++ {
+        marker := "  "
+        if address == d.Program.Position {
+            marker = "=>"
+        }
+
+        line := DisassembledLine{Address: address, Words: memory[address:address+1], Mnemonic: DataMnemonic, Operands: []string{fmt.Sprint(memory[address])}}
+        if instruction, ok := DecodeInstruction(memory, address); ok {
+            line = disassembleInstruction(memory, address, instruction)
+        }
+
+        fmt.Fprintln(d.out, marker, line)
+        address += len(line.Words)
+    }
+}
+
+func (d *Debugger) printMemory(address int, count int) {
+    for row := address; row < address + count; row += 8 {
+        var values []string
+        for k := row; k < row + 8 && k < address + count; k++ {
+            if value, err := d.Program.ReadMemory(int64(k)); err != nil {
+                values = append(values, "-")
+            } else {
+                values = append(values, fmt.Sprint(value))
+            }
+        }
+        fmt.Fprintf(d.out, "%5d: %s\n", row, strings.Join(values, " "))
+    }
+}
+
+func parseOptionalInts(args []string, targets ...*int) error {
+    for k, arg := range args {
+        if k >= len(targets) {
+            return fmt.Errorf("too many arguments")
+        }
+        value, err := strconv.Atoi(arg)
+        if err != nil {
+            return err
+        }
+        *targets[k] = value
+    }
+    return nil
+}
@@ -0,0 +1,123 @@
+package intcode
+
+import (
+    "bytes"
+    "context"
+    "strings"
+    "testing"
+)
+
+// Runs the debugger commands (one per line) and returns everything it has written
+func runDebugger(p *Program, script ...string) string {
+    var out bytes.Buffer
+    NewDebugger(p, strings.NewReader(strings.Join(script, "\n")), &out).Run()
+    return out.String()
+}
+
+// Checks that the output contains the parts in the given order
+func checkDebuggerOutput(t *testing.T, out string, parts ...string) {
+    t.Helper()
+    rest := out
+    for _, part := range parts {
+        index := strings.Index(rest, part)
+        if index < 0 {
+            t.Fatalf("missing %q in the output:\n%s", part, out)
+        }
+        rest = rest[index + len(part):]
+    }
+}
+
+func TestDebugger(t *testing.T) {
+    // Inputs are pushed by the debugger, see journalSource for the addresses
+    p := newJournalProgram(t, 0)
+    p.Input = nil
+
+    out := runDebugger(p,
+        "s",
+        "in 5",
+        "b OUT",
+        "c",
+        "c",
+        "set 14 9",
+        "x 13 2",
+        "in 7",
+        "io",
+        "b 10",
+        "d OUT",
+        "bl",
+        "c",
+        "c",
+        "r",
+    )
+    checkDebuggerOutput(t, out,
+        "program waits for input",
+        "breakpoint reached", "=>     6", "OUT  [14]",
+        "program outputs: [6]", "program waits for input", "=>     8",
+        "   13: 5 9",
+        "pending input: [7]", "output: [6]",
+        "addresses: [10]", "operations: []",
+        "breakpoint reached", "=>    10",
+        "program outputs: [7]", "program has completed",
+        "position: 12", "completed: true",
+    )
+    checkOutputs(t, p, 6, 7)
+    if value, _ := p.ReadMemory(14); value != 9 {
+        t.Errorf("b = %d after set, expected 9", value)
+    }
+}
+
+func TestDebuggerWatchAndStepBack(t *testing.T) {
+    p := newJournalProgram(t, 0, 5, 7)
+
+    out := runDebugger(p,
+        "w 14:w",
+        "c",
+        "sb",
+        "r",
+        "uw 14",
+        "c",
+        "audit",
+    )
+    checkDebuggerOutput(t, out,
+        "watchpoint:", "watchpoint reached", "=>     6",
+        "=>     2", "position: 2",
+        "program has completed",
+        "3 writes into the loaded code",
+    )
+    checkOutputs(t, p, 6, 7)
+}
+
+func TestDebuggerWaitsForInput(t *testing.T) {
+    code, err := Assemble("IN [a]\nIN [a]\nHLT\na: data 0")
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    tests := []struct {
+        name      string
+        input     Input
+        completes bool
+    }{
+        {"queue", NewQueueInput(1), false},
+        {"ascii without reader", NewASCIIInput("A"), true},
+        {"ascii queue runs out", NewASCIIInput(""), false},
+        {"ascii reader", NewASCIIReaderInput(strings.NewReader("A\n")), true},
+        // Inputs that can not tell whether they have a value are trusted
+        {"function", InputFunc(func(ctx context.Context) (int64, error) { return 1, nil }), true},
+    }
+
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            p := &Program{Quiet: true, Input: test.input}
+            p.LoadCode(code)
+            out := runDebugger(p, "c")
+
+            if p.Completed != test.completes {
+                t.Errorf("completed %v, expected %v:\n%s", p.Completed, test.completes, out)
+            }
+            if waits := strings.Contains(out, "program waits for input"); waits == test.completes {
+                t.Errorf("waits for input %v:\n%s", waits, out)
+            }
+        })
+    }
+}
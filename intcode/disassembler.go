@@ -0,0 +1,130 @@
+package intcode
+
+import (
+    "fmt"
+    "strings"
+)
+
+var (
+    Mnemonics = map[InstructionOperation]string{
+        Add:"ADD", Multiply:"MUL", Read:"IN", Write:"OUT", JumpIfTrue:"JT", JumpIfFalse:"JF", LessThan:"LT", Equals:"EQ", SetRelativeBase:"ARB", Terminate:"HLT",
+    }
+)
+
+// DataMnemonic is used for words that do not decode into a valid instruction
+const DataMnemonic = "DATA"
+
+// DisassembledLine is a single decoded instruction (or a single data word) of the program
+type DisassembledLine struct {
+    Address  int
+    Words    []int64
+    Mnemonic string
+    Operands []string
+}
+
+func (l DisassembledLine) String() string {
+    words := make([]string, len(l.Words))
+    for i, word := range l.Words {
+        words[i] = fmt.Sprint(word)
+    }
+
+    return fmt.Sprintf("%5d  %-40s %s", l.Address, strings.Join(words, ","), l.Source())
+}
+
+// Source renders only the mnemonic and operands, in the format accepted by Assemble
+func (l DisassembledLine) Source() string {
+    return strings.TrimRight(fmt.Sprintf("%-4s %s", l.Mnemonic, strings.Join(l.Operands, ", ")), " ")
+}
+
+// Disassemble decodes the code sequentially from the first address. Words that can not be decoded as an instruction
+// (unknown operation, invalid parameter mode, write in immediate mode or truncated parameters) are reported as DATA
+// and decoding continues with the following word.
+func Disassemble(code []int64) []DisassembledLine {
+    var lines []DisassembledLine
+
+    for address := 0; address < len(code); {
+        instruction, ok := DecodeInstruction(code, address)
+        if !ok {
+            lines = append(lines, DisassembledLine{
+                Address:  address,
+                Words:    code[address:address+1],
+                Mnemonic: DataMnemonic,
+                Operands: []string{fmt.Sprint(code[address])},
+            })
+            address++
+            continue
+        }
+
+        lines = append(lines, disassembleInstruction(code, address, instruction))
+        address += instruction.Length
+    }
+
+    return lines
+}
+
+func disassembleInstruction(code []int64, address int, instruction Instruction) DisassembledLine {
+    operands := make([]string, len(instruction.Params))
+    for j, param := range instruction.Params {
+        operands[j] = FormatOperand(param)
+    }
+
+    return DisassembledLine{
+        Address:  address,
+        Words:    code[address:address+instruction.Length],
+        Mnemonic: Mnemonics[instruction.Operation],
+        Operands: operands,
+    }
+}
+
+// DecodeInstruction decodes the instruction at given address and reports whether it is a valid one.
+func DecodeInstruction(code []int64, address int) (Instruction, bool) {
+    var instruction Instruction
+
+    if address < 0 || address >= len(code) || code[address] <= 0 {
+        return instruction, false
+    }
+
+    length, ok := InstructionLength[InstructionOperation(code[address] % 100)]
+    if !ok || address + length > len(code) || code[address] >= int64(pow10(length + 1)) {
+        return instruction, false
+    }
+
+    instruction.Initialize(code, address)
+
+    for j, param := range instruction.Params {
+        if param.Mode > RelativeMode {
+            return instruction, false
+        }
+        if param.Mode == ImmediateMode && j >= instruction.getValuesCount() && instruction.doesStoreOutputInMemory() {
+            return instruction, false
+        }
+    }
+
+    return instruction, true
+}
+
+// FormatOperand renders the parameter with marker of its mode - [x] for position, #x for immediate value
+// and rb+x for address relative to the relative base.
+func FormatOperand(param InstructionParam) string {
+    switch param.Mode {
+    case PositionMode:
+        return fmt.Sprintf("[%d]", param.Value)
+    case ImmediateMode:
+        return fmt.Sprintf("#%d", param.Value)
+    case RelativeMode:
+        if param.Value < 0 {
+            return fmt.Sprintf("rb%d", param.Value)
+        }
+        return fmt.Sprintf("rb+%d", param.Value)
+    default:
+        return fmt.Sprintf("?%d", param.Value)
+    }
+}
+
+func pow10(n int) int {
+    result := 1
+    for k := 0; k < n; k++ {
+        result *= 10
+    }
+    return result
+}
@@ -0,0 +1,37 @@
+package intcode
+
+import (
+    "reflect"
+    "strings"
+    "testing"
+)
+
+// Source of the disassembled code has to assemble back into the same code, data words included
+func TestDisassembleRoundTrip(t *testing.T) {
+    for _, day := range []string{"2", "5", "7", "9", "11"} {
+        t.Run("day " + day, func(t *testing.T) {
+            code, err := ReadCodeFile("../" + day + "/code")
+            if err != nil {
+                t.Fatal(err)
+            }
+
+            var source []string
+            for _, line := range Disassemble(code) {
+                source = append(source, line.Source())
+            }
+
+            assembled, err := Assemble(strings.Join(source, "\n"))
+            if err != nil {
+                t.Fatal(err)
+            }
+            if !reflect.DeepEqual(assembled, code) {
+                for address := range code {
+                    if address >= len(assembled) || assembled[address] != code[address] {
+                        t.Fatalf("assembled code differs from address %d", address)
+                    }
+                }
+                t.Fatalf("assembled code has %d words instead of %d", len(assembled), len(code))
+            }
+        })
+    }
+}
@@ -0,0 +1,125 @@
+package intcode
+
+import (
+    "fmt"
+    "strings"
+)
+
+// InvalidOpcodeError is returned when the word on the instruction position is not a known operation
+type InvalidOpcodeError struct {
+    Address int
+    Value   int64
+}
+
+func (e *InvalidOpcodeError) Error() string {
+    return fmt.Sprintf("invalid opcode %d at address %d", e.Value, e.Address)
+}
+
+// InvalidModeError is returned when the instruction specifies parameter mode other than 0, 1 or 2
+type InvalidModeError struct {
+    Address   int
+    Value     int64
+    Parameter int
+    Mode      int
+}
+
+func (e *InvalidModeError) Error() string {
+    return fmt.Sprintf("invalid mode %d of parameter %d in instruction %d at address %d", e.Mode, e.Parameter + 1, e.Value, e.Address)
+}
+
+// ImmediateWriteError is returned when the parameter the instruction writes to is in immediate mode
+type ImmediateWriteError struct {
+    Address int
+    Value   int64
+}
+
+func (e *ImmediateWriteError) Error() string {
+    return fmt.Sprintf("instruction %d at address %d writes to immediate mode parameter", e.Value, e.Address)
+}
+
+// MemoryFaultError is returned when the instruction on Address accesses negative memory address Target
+type MemoryFaultError struct {
+    Address int
+    Target  int64
+    Write   bool
+}
+
+func (e *MemoryFaultError) Error() string {
+    access := "read from"
+    if e.Write {
+        access = "write to"
+    }
+    return fmt.Sprintf("memory %s negative address %d by instruction at address %d", access, e.Target, e.Address)
+}
+
+// InputStarvedError is returned when the program needs input that is not available. Err is either ErrNoInput
+// or the error of the context that ended while the program was waiting for the input.
+type InputStarvedError struct {
+    Address int
+    Err     error
+}
+
+func (e *InputStarvedError) Error() string {
+    return fmt.Sprintf("input starved at address %d: %v", e.Address, e.Err)
+}
+
+func (e *InputStarvedError) Unwrap() error {
+    return e.Err
+}
+
+// ParseError is returned when the program code contains a value that is not an integer
+type ParseError struct {
+    Index int
+    Text  string
+    Err   error
+}
+
+func (e *ParseError) Error() string {
+    return fmt.Sprintf("invalid value %q at index %d of the code: %v", e.Text, e.Index, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+    return e.Err
+}
+
+// OverflowError is returned when the result of Add or Multiply instruction with operands A and B does not fit
+// into int64 in CheckedArithmetic mode, or when a value that does not fit into int64 is used where it has to
+// (like jump target or the instruction itself, then Operation is 0) in BigArithmetic mode.
+type OverflowError struct {
+    Address   int
+    Operation InstructionOperation
+    A         int64
+    B         int64
+}
+
+func (e *OverflowError) Error() string {
+    if e.Operation == Add || e.Operation == Multiply {
+        return fmt.Sprintf("integer overflow in %s %d, %d at address %d", Mnemonics[e.Operation], e.A, e.B, e.Address)
+    }
+    if e.Operation == 0 {
+        return fmt.Sprintf("instruction at address %d does not fit into int64", e.Address)
+    }
+    return fmt.Sprintf("operand of %s at address %d does not fit into int64", Mnemonics[e.Operation], e.Address)
+}
+
+// BudgetExhaustedError is returned when the instruction on Address costs more than the rest of the Budget
+// of the program
+type BudgetExhaustedError struct {
+    Address int
+    Budget  int64
+    Used    int64
+    Cost    int64
+}
+
+func (e *BudgetExhaustedError) Error() string {
+    return fmt.Sprintf("budget exhausted at address %d, instruction costs %d and %d of %d is used", e.Address, e.Cost, e.Used, e.Budget)
+}
+
+// DeadlockError is returned by Network when all its running machines wait for input that nobody can send them
+type DeadlockError struct {
+    Machines []string
+}
+
+func (e *DeadlockError) Error() string {
+    return fmt.Sprintf("deadlock, machines %s wait for input", strings.Join(e.Machines, ", "))
+}
@@ -0,0 +1,92 @@
+package intcode
+
+import (
+    "errors"
+    "reflect"
+    "strconv"
+    "testing"
+)
+
+func TestExecuteErrors(t *testing.T) {
+    tests := []struct {
+        name     string
+        code     string
+        // Pointer to the error type, errors.As stores the returned error into it
+        target   interface{}
+        expected error
+    }{
+        {
+            name:     "invalid opcode",
+            code:     "1,0,0,0,42",
+            target:   new(*InvalidOpcodeError),
+            expected: &InvalidOpcodeError{Address: 4, Value: 42},
+        },
+        {
+            name:     "invalid mode",
+            code:     "1,0,0,0,3001,0,0,0,99",
+            target:   new(*InvalidModeError),
+            expected: &InvalidModeError{Address: 4, Value: 3001, Parameter: 1, Mode: 3},
+        },
+        {
+            name:     "immediate write",
+            code:     "11101,1,1,3,99",
+            target:   new(*ImmediateWriteError),
+            expected: &ImmediateWriteError{Address: 0, Value: 11101},
+        },
+        {
+            name:     "read from negative address",
+            code:     "1101,1,1,0,1,-5,0,0,99",
+            target:   new(*MemoryFaultError),
+            expected: &MemoryFaultError{Address: 4, Target: -5},
+        },
+        {
+            name:     "write to negative address",
+            code:     "109,-10,21101,1,1,0,99",
+            target:   new(*MemoryFaultError),
+            expected: &MemoryFaultError{Address: 2, Target: -10, Write: true},
+        },
+        {
+            name:     "input starved",
+            code:     "1101,1,1,5,3,0,99",
+            target:   new(*InputStarvedError),
+            expected: &InputStarvedError{Address: 4, Err: ErrNoInput},
+        },
+    }
+
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            code, err := ParseCode(test.code)
+            if err != nil {
+                t.Fatal(err)
+            }
+
+            program := &Program{Quiet: true, Input: NewQueueInput()}
+            program.LoadCode(code)
+            err = program.Execute()
+            if !errors.As(err, test.target) {
+                t.Fatalf("expected %T, got %v", test.expected, err)
+            }
+            if actual := reflect.ValueOf(test.target).Elem().Interface(); !reflect.DeepEqual(actual, test.expected) {
+                t.Errorf("error %#v, expected %#v", actual, test.expected)
+            }
+            if !program.Completed {
+                t.Error("program that failed is not completed")
+            }
+        })
+    }
+}
+
+func TestParseCodeError(t *testing.T) {
+    _, err := ParseCode("1, 2, x, 4")
+
+    var parseErr *ParseError
+    if !errors.As(err, &parseErr) {
+        t.Fatalf("expected parse error, got %v", err)
+    }
+    if parseErr.Index != 2 || parseErr.Text != " x" {
+        t.Errorf("invalid value %q at index %d, expected \" x\" at 2", parseErr.Text, parseErr.Index)
+    }
+    if !errors.Is(err, strconv.ErrSyntax) {
+        t.Errorf("error %v does not wrap syntax error", err)
+    }
+}
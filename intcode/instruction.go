@@ -0,0 +1,91 @@
+// Package intcode implements the Intcode computer used by several Advent of Code 2019 puzzles.
+package intcode
+
+type InstructionOperation int
+
+const (
+    Add             InstructionOperation = 1
+    Multiply        InstructionOperation = 2
+    Read            InstructionOperation = 3
+    Write           InstructionOperation = 4
+    JumpIfTrue      InstructionOperation = 5
+    JumpIfFalse     InstructionOperation = 6
+    LessThan        InstructionOperation = 7
+    Equals          InstructionOperation = 8
+    SetRelativeBase InstructionOperation = 9
+    Terminate       InstructionOperation = 99
+)
+
+// Parameter modes that can be encoded in the instruction code
+const (
+    PositionMode  = 0
+    ImmediateMode = 1
+    RelativeMode  = 2
+)
+
+var (
+    InstructionLength = map[InstructionOperation]int{
+        Add:4, Multiply:4, Read:2, Write:2, JumpIfTrue:3, JumpIfFalse:3, LessThan:4, Equals:4, SetRelativeBase:2, Terminate:1,
+    }
+)
+
+type Instruction struct {
+    Operation InstructionOperation
+    Length    int
+    Params    []InstructionParam
+}
+
+type InstructionParam struct {
+    Mode  int
+    Value int64
+}
+
+// Initialize decodes the instruction stored at given position of the intCode. Params of the instruction are reused
+// when they have enough capacity, so decoding into the same Instruction repeatedly does not allocate.
+func (i *Instruction) Initialize(intCode []int64, pIndex int) {
+    instValue := int(intCode[pIndex])
+
+    // Standard Operation Codes are between 1 and 99, larger number means that Parameter Modes are included there
+    i.Operation = InstructionOperation(instValue % 100)
+    i.Length = InstructionLength[i.Operation]
+    paramCount := i.Length - 1
+
+    // Unknown Operation Codes have no length, they are decoded without parameters
+    if paramCount < 0 {
+        paramCount = 0
+    }
+    if cap(i.Params) >= paramCount {
+        i.Params = i.Params[:paramCount]
+    } else {
+        i.Params = make([]InstructionParam, paramCount, paramCount)
+    }
+
+    // Parameter Mode is either 0 (by reference), 1 (by value) or 2 (relative reference) and this mode
+    // is specified in the Instruction code itself (as given digit at respective position, starting with hundreds)
+    modes := instValue / 100
+    for j := 0; j < paramCount; j++ {
+        i.Params[j] = InstructionParam{modes % 10, intCode[pIndex+j+1]}
+        modes /= 10
+    }
+}
+
+// Number of parameters that are read as values (the remaining parameter, if any, is the write address).
+func (i *Instruction) getValuesCount() int {
+    switch i.Operation {
+    case Add, Multiply, JumpIfTrue, JumpIfFalse, LessThan, Equals:
+        return 2
+    case Write, SetRelativeBase:
+        return 1
+    default:
+        return 0
+    }
+}
+
+func isJump(operation InstructionOperation) bool {
+    return operation == JumpIfTrue || operation == JumpIfFalse
+}
+
+func (i *Instruction) doesStoreOutputInMemory() bool {
+    return i.Operation == Read || i.Operation == Add || i.Operation == Multiply || i.Operation == LessThan || i.Operation == Equals
+}
+
@@ -0,0 +1,1654 @@
+// Code generated by intcode transpiler from ../../../9/code. DO NOT EDIT.
+
+package day9
+
+import (
+	"context"
+
+	"github.com/tomasbobek/AdventOfCode19/intcode"
+)
+
+var code = []int64{1102, 34463338, 34463338, 63, 1007, 63, 34463338, 63, 1005, 63, 53, 1101, 0, 3, 1000, 109, 988, 209, 12, 9, 1000, 209, 6, 209, 3, 203, 0, 1008, 1000, 1, 63, 1005, 63, 65, 1008, 1000, 2, 63, 1005, 63, 904, 1008, 1000, 0, 63, 1005, 63, 58, 4, 25, 104, 0, 99, 4, 0, 104, 0, 99, 4, 17, 104, 0, 99, 0, 0, 1102, 1, 29, 1011, 1102, 1, 27, 1009, 1101, 23, 0, 1008, 1101, 0, 25, 1017, 1102, 1, 36, 1016, 1101, 0, 31, 1018, 1102, 35, 1, 1012, 1101, 28, 0, 1004, 1101, 779, 0, 1024, 1102, 403, 1, 1026, 1101, 0, 33, 1010, 1102, 37, 1, 1015, 1101, 32, 0, 1014, 1101, 0, 752, 1023, 1101, 0, 30, 1013, 1102, 21, 1, 1001, 1102, 1, 1, 1021, 1102, 1, 34, 1002, 1102, 400, 1, 1027, 1101, 0, 22, 1007, 1102, 1, 567, 1028, 1101, 558, 0, 1029, 1102, 26, 1, 1006, 1102, 39, 1, 1005, 1102, 1, 0, 1020, 1101, 0, 38, 1000, 1101, 0, 755, 1022, 1102, 1, 770, 1025, 1102, 1, 24, 1003, 1102, 20, 1, 1019, 109, 28, 21107, 40, 41, -9, 1005, 1019, 199, 4, 187, 1106, 0, 203, 1001, 64, 1, 64, 1002, 64, 2, 64, 109, -30, 2107, 38, 7, 63, 1005, 63, 221, 4, 209, 1105, 1, 225, 1001, 64, 1, 64, 1002, 64, 2, 64, 109, -5, 2102, 1, 8, 63, 1008, 63, 21, 63, 1005, 63, 251, 4, 231, 1001, 64, 1, 64, 1106, 0, 251, 1002, 64, 2, 64, 109, 21, 1207, -7, 21, 63, 1005, 63, 267, 1105, 1, 273, 4, 257, 1001, 64, 1, 64, 1002, 64, 2, 64, 109, -1, 1201, -7, 0, 63, 1008, 63, 29, 63, 1005, 63, 293, 1106, 0, 299, 4, 279, 1001, 64, 1, 64, 1002, 64, 2, 64, 109, -4, 1202, -3, 1, 63, 1008, 63, 28, 63, 1005, 63, 319, 1106, 0, 325, 4, 305, 1001, 64, 1, 64, 1002, 64, 2, 64, 109, 14, 1206, -3, 343, 4, 331, 1001, 64, 1, 64, 1106, 0, 343, 1002, 64, 2, 64, 109, -14, 2108, 21, -8, 63, 1005, 63, 361, 4, 349, 1105, 1, 365, 1001, 64, 1, 64, 1002, 64, 2, 64, 109, -9, 1201, 9, 0, 63, 1008, 63, 27, 63, 1005, 63, 391, 4, 371, 1001, 64, 1, 64, 1106, 0, 391, 1002, 64, 2, 64, 109, 27, 2106, 0, 0, 1106, 0, 409, 4, 397, 1001, 64, 1, 64, 1002, 64, 2, 64, 109, -20, 2101, 0, 0, 63, 1008, 63, 22, 63, 1005, 63, 431, 4, 415, 1105, 1, 435, 1001, 64, 1, 64, 1002, 64, 2, 64, 109, -7, 1202, 7, 1, 63, 1008, 63, 22, 63, 1005, 63, 457, 4, 441, 1105, 1, 461, 1001, 64, 1, 64, 1002, 64, 2, 64, 109, 8, 1208, 0, 23, 63, 1005, 63, 479, 4, 467, 1106, 0, 483, 1001, 64, 1, 64, 1002, 64, 2, 64, 109, 20, 1205, -8, 495, 1105, 1, 501, 4, 489, 1001, 64, 1, 64, 1002, 64, 2, 64, 109, -26, 1208, 4, 28, 63, 1005, 63, 521, 1001, 64, 1, 64, 1105, 1, 523, 4, 507, 1002, 64, 2, 64, 109, 15, 21102, 41, 1, -2, 1008, 1015, 41, 63, 1005, 63, 545, 4, 529, 1106, 0, 549, 1001, 64, 1, 64, 1002, 64, 2, 64, 109, 18, 2106, 0, -7, 4, 555, 1001, 64, 1, 64, 1106, 0, 567, 1002, 64, 2, 64, 109, -30, 1207, -3, 35, 63, 1005, 63, 585, 4, 573, 1105, 1, 589, 1001, 64, 1, 64, 1002, 64, 2, 64, 109, 14, 1206, 2, 605, 1001, 64, 1, 64, 1106, 0, 607, 4, 595, 1002, 64, 2, 64, 109, -3, 1205, 5, 621, 4, 613, 1106, 0, 625, 1001, 64, 1, 64, 1002, 64, 2, 64, 109, -5, 21107, 42, 41, 2, 1005, 1013, 645, 1001, 64, 1, 64, 1106, 0, 647, 4, 631, 1002, 64, 2, 64, 109, -11, 2108, 42, 5, 63, 1005, 63, 663, 1106, 0, 669, 4, 653, 1001, 64, 1, 64, 1002, 64, 2, 64, 109, 4, 21102, 43, 1, 9, 1008, 1013, 40, 63, 1005, 63, 693, 1001, 64, 1, 64, 1106, 0, 695, 4, 675, 1002, 64, 2, 64, 109, -1, 2107, 22, -2, 63, 1005, 63, 715, 1001, 64, 1, 64, 1106, 0, 717, 4, 701, 1002, 64, 2, 64, 109, 7, 21101, 44, 0, 0, 1008, 1010, 45, 63, 1005, 63, 741, 1001, 64, 1, 64, 1106, 0, 743, 4, 723, 1002, 64, 2, 64, 109, 9, 2105, 1, 4, 1106, 0, 761, 4, 749, 1001, 64, 1, 64, 1002, 64, 2, 64, 109, 10, 2105, 1, -5, 4, 767, 1001, 64, 1, 64, 1105, 1, 779, 1002, 64, 2, 64, 109, -22, 21108, 45, 43, 10, 1005, 1017, 799, 1001, 64, 1, 64, 1105, 1, 801, 4, 785, 1002, 64, 2, 64, 109, 16, 21101, 46, 0, -8, 1008, 1015, 46, 63, 1005, 63, 827, 4, 807, 1001, 64, 1, 64, 1105, 1, 827, 1002, 64, 2, 64, 109, -7, 2101, 0, -7, 63, 1008, 63, 29, 63, 1005, 63, 851, 1001, 64, 1, 64, 1106, 0, 853, 4, 833, 1002, 64, 2, 64, 109, -5, 2102, 1, -3, 63, 1008, 63, 22, 63, 1005, 63, 877, 1001, 64, 1, 64, 1106, 0, 879, 4, 859, 1002, 64, 2, 64, 109, 9, 21108, 47, 47, -5, 1005, 1015, 897, 4, 885, 1105, 1, 901, 1001, 64, 1, 64, 4, 64, 99, 21102, 27, 1, 1, 21101, 0, 915, 0, 1105, 1, 922, 21201, 1, 61784, 1, 204, 1, 99, 109, 3, 1207, -2, 3, 63, 1005, 63, 964, 21201, -2, -1, 1, 21101, 942, 0, 0, 1105, 1, 922, 22102, 1, 1, -1, 21201, -2, -3, 1, 21102, 1, 957, 0, 1106, 0, 922, 22201, 1, -1, -2, 1105, 1, 968, 22101, 0, -2, -2, 109, -3, 2105, 1, 0}
+
+// Addresses of the instructions translated in Run
+var entries = []int{0, 4, 8, 11, 15, 17, 19, 21, 23, 25, 27, 31, 34, 38, 41, 45, 48, 50, 52, 53, 55, 57, 58, 60, 62, 65, 69, 73, 77, 81, 85, 89, 93, 97, 101, 105, 109, 113, 117, 121, 125, 129, 133, 137, 141, 145, 149, 153, 157, 161, 165, 169, 173, 177, 181, 185, 187, 191, 194, 196, 199, 203, 207, 209, 213, 216, 218, 221, 225, 229, 231, 235, 239, 242, 244, 248, 251, 255, 257, 261, 264, 267, 269, 273, 277, 279, 283, 287, 290, 293, 295, 299, 303, 305, 309, 313, 316, 319, 321, 325, 329, 331, 334, 336, 340, 343, 347, 349, 353, 356, 358, 361, 365, 369, 371, 375, 379, 382, 384, 388, 391, 395, 397, 400, 403, 405, 409, 413, 415, 419, 423, 426, 428, 431, 435, 439, 441, 445, 449, 452, 454, 457, 461, 465, 467, 471, 474, 476, 479, 483, 487, 489, 492, 495, 497, 501, 505, 507, 511, 514, 518, 521, 523, 527, 529, 533, 537, 540, 542, 545, 549, 553, 555, 558, 560, 564, 567, 571, 573, 577, 580, 582, 585, 589, 593, 595, 598, 602, 605, 607, 611, 613, 616, 618, 621, 625, 629, 631, 635, 638, 642, 645, 647, 651, 653, 657, 660, 663, 665, 669, 673, 675, 679, 683, 686, 690, 693, 695, 699, 701, 705, 708, 712, 715, 717, 721, 723, 727, 731, 734, 738, 741, 743, 747, 749, 752, 755, 757, 761, 765, 767, 770, 772, 776, 779, 783, 785, 789, 792, 796, 799, 801, 805, 807, 811, 815, 818, 820, 824, 827, 831, 833, 837, 841, 844, 848, 851, 853, 857, 859, 863, 867, 870, 874, 877, 879, 883, 885, 889, 892, 894, 897, 901, 903, 904, 908, 912, 915, 919, 921, 922, 924, 928, 931, 935, 939, 942, 946, 950, 954, 957, 961, 964, 968, 970}
+
+// Run executes the program, it reads inputs from input and writes outputs to output
+func Run(ctx context.Context, input intcode.Input, output intcode.Output) (err error) {
+	r := intcode.NewNativeRuntime(ctx, code, entries, input, output)
+	defer r.Recover(&err)
+
+	pc, rb := 0, 0
+	for steps := 0; ; steps++ {
+		if steps%1024 == 0 && ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		switch pc {
+		case 0: // MUL  #34463338, #34463338, [63]
+			pc = 4
+			if r.Store(0, 63, 34463338*34463338) {
+				return r.Interpret(pc, rb)
+			}
+		case 4: // LT   [63], #34463338, [63]
+			flag := int64(0)
+			if r.Load(4, 63) < 34463338 {
+				flag = 1
+			}
+			pc = 8
+			if r.Store(4, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 8: // JT   [63], #53
+			value, target := r.Load(8, 63), 53
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 11
+			}
+		case 11: // ADD  #0, #3, [1000]
+			pc = 15
+			if r.Store(11, 1000, 0+3) {
+				return r.Interpret(pc, rb)
+			}
+		case 15: // ARB  #988
+			rb += int(988)
+			pc = 17
+		case 17: // ARB  rb+12
+			rb += int(r.Load(17, int64(rb)+12))
+			pc = 19
+		case 19: // ARB  [1000]
+			rb += int(r.Load(19, 1000))
+			pc = 21
+		case 21: // ARB  rb+6
+			rb += int(r.Load(21, int64(rb)+6))
+			pc = 23
+		case 23: // ARB  rb+3
+			rb += int(r.Load(23, int64(rb)+3))
+			pc = 25
+		case 25: // IN   rb+0
+			pc = 27
+			if r.Store(25, int64(rb)+0, r.In(25)) {
+				return r.Interpret(pc, rb)
+			}
+		case 27: // EQ   [1000], #1, [63]
+			flag := int64(0)
+			if r.Load(27, 1000) == 1 {
+				flag = 1
+			}
+			pc = 31
+			if r.Store(27, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 31: // JT   [63], #65
+			value, target := r.Load(31, 63), 65
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 34
+			}
+		case 34: // EQ   [1000], #2, [63]
+			flag := int64(0)
+			if r.Load(34, 1000) == 2 {
+				flag = 1
+			}
+			pc = 38
+			if r.Store(34, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 38: // JT   [63], #904
+			value, target := r.Load(38, 63), 904
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 41
+			}
+		case 41: // EQ   [1000], #0, [63]
+			flag := int64(0)
+			if r.Load(41, 1000) == 0 {
+				flag = 1
+			}
+			pc = 45
+			if r.Store(41, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 45: // JT   [63], #58
+			value, target := r.Load(45, 63), 58
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 48
+			}
+		case 48: // OUT  [25]
+			r.Out(48, r.Load(48, 25))
+			pc = 50
+		case 50: // OUT  #0
+			r.Out(50, 0)
+			pc = 52
+		case 52: // HLT
+			return nil
+		case 53: // OUT  [0]
+			r.Out(53, r.Load(53, 0))
+			pc = 55
+		case 55: // OUT  #0
+			r.Out(55, 0)
+			pc = 57
+		case 57: // HLT
+			return nil
+		case 58: // OUT  [17]
+			r.Out(58, r.Load(58, 17))
+			pc = 60
+		case 60: // OUT  #0
+			r.Out(60, 0)
+			pc = 62
+		case 62: // HLT
+			return nil
+		case 65: // MUL  #1, #29, [1011]
+			pc = 69
+			if r.Store(65, 1011, 1*29) {
+				return r.Interpret(pc, rb)
+			}
+		case 69: // MUL  #1, #27, [1009]
+			pc = 73
+			if r.Store(69, 1009, 1*27) {
+				return r.Interpret(pc, rb)
+			}
+		case 73: // ADD  #23, #0, [1008]
+			pc = 77
+			if r.Store(73, 1008, 23+0) {
+				return r.Interpret(pc, rb)
+			}
+		case 77: // ADD  #0, #25, [1017]
+			pc = 81
+			if r.Store(77, 1017, 0+25) {
+				return r.Interpret(pc, rb)
+			}
+		case 81: // MUL  #1, #36, [1016]
+			pc = 85
+			if r.Store(81, 1016, 1*36) {
+				return r.Interpret(pc, rb)
+			}
+		case 85: // ADD  #0, #31, [1018]
+			pc = 89
+			if r.Store(85, 1018, 0+31) {
+				return r.Interpret(pc, rb)
+			}
+		case 89: // MUL  #35, #1, [1012]
+			pc = 93
+			if r.Store(89, 1012, 35*1) {
+				return r.Interpret(pc, rb)
+			}
+		case 93: // ADD  #28, #0, [1004]
+			pc = 97
+			if r.Store(93, 1004, 28+0) {
+				return r.Interpret(pc, rb)
+			}
+		case 97: // ADD  #779, #0, [1024]
+			pc = 101
+			if r.Store(97, 1024, 779+0) {
+				return r.Interpret(pc, rb)
+			}
+		case 101: // MUL  #403, #1, [1026]
+			pc = 105
+			if r.Store(101, 1026, 403*1) {
+				return r.Interpret(pc, rb)
+			}
+		case 105: // ADD  #0, #33, [1010]
+			pc = 109
+			if r.Store(105, 1010, 0+33) {
+				return r.Interpret(pc, rb)
+			}
+		case 109: // MUL  #37, #1, [1015]
+			pc = 113
+			if r.Store(109, 1015, 37*1) {
+				return r.Interpret(pc, rb)
+			}
+		case 113: // ADD  #32, #0, [1014]
+			pc = 117
+			if r.Store(113, 1014, 32+0) {
+				return r.Interpret(pc, rb)
+			}
+		case 117: // ADD  #0, #752, [1023]
+			pc = 121
+			if r.Store(117, 1023, 0+752) {
+				return r.Interpret(pc, rb)
+			}
+		case 121: // ADD  #0, #30, [1013]
+			pc = 125
+			if r.Store(121, 1013, 0+30) {
+				return r.Interpret(pc, rb)
+			}
+		case 125: // MUL  #21, #1, [1001]
+			pc = 129
+			if r.Store(125, 1001, 21*1) {
+				return r.Interpret(pc, rb)
+			}
+		case 129: // MUL  #1, #1, [1021]
+			pc = 133
+			if r.Store(129, 1021, 1*1) {
+				return r.Interpret(pc, rb)
+			}
+		case 133: // MUL  #1, #34, [1002]
+			pc = 137
+			if r.Store(133, 1002, 1*34) {
+				return r.Interpret(pc, rb)
+			}
+		case 137: // MUL  #400, #1, [1027]
+			pc = 141
+			if r.Store(137, 1027, 400*1) {
+				return r.Interpret(pc, rb)
+			}
+		case 141: // ADD  #0, #22, [1007]
+			pc = 145
+			if r.Store(141, 1007, 0+22) {
+				return r.Interpret(pc, rb)
+			}
+		case 145: // MUL  #1, #567, [1028]
+			pc = 149
+			if r.Store(145, 1028, 1*567) {
+				return r.Interpret(pc, rb)
+			}
+		case 149: // ADD  #558, #0, [1029]
+			pc = 153
+			if r.Store(149, 1029, 558+0) {
+				return r.Interpret(pc, rb)
+			}
+		case 153: // MUL  #26, #1, [1006]
+			pc = 157
+			if r.Store(153, 1006, 26*1) {
+				return r.Interpret(pc, rb)
+			}
+		case 157: // MUL  #39, #1, [1005]
+			pc = 161
+			if r.Store(157, 1005, 39*1) {
+				return r.Interpret(pc, rb)
+			}
+		case 161: // MUL  #1, #0, [1020]
+			pc = 165
+			if r.Store(161, 1020, 1*0) {
+				return r.Interpret(pc, rb)
+			}
+		case 165: // ADD  #0, #38, [1000]
+			pc = 169
+			if r.Store(165, 1000, 0+38) {
+				return r.Interpret(pc, rb)
+			}
+		case 169: // ADD  #0, #755, [1022]
+			pc = 173
+			if r.Store(169, 1022, 0+755) {
+				return r.Interpret(pc, rb)
+			}
+		case 173: // MUL  #1, #770, [1025]
+			pc = 177
+			if r.Store(173, 1025, 1*770) {
+				return r.Interpret(pc, rb)
+			}
+		case 177: // MUL  #1, #24, [1003]
+			pc = 181
+			if r.Store(177, 1003, 1*24) {
+				return r.Interpret(pc, rb)
+			}
+		case 181: // MUL  #20, #1, [1019]
+			pc = 185
+			if r.Store(181, 1019, 20*1) {
+				return r.Interpret(pc, rb)
+			}
+		case 185: // ARB  #28
+			rb += int(28)
+			pc = 187
+		case 187: // LT   #40, #41, rb-9
+			flag := int64(0)
+			if 40 < 41 {
+				flag = 1
+			}
+			pc = 191
+			if r.Store(187, int64(rb)+-9, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 191: // JT   [1019], #199
+			value, target := r.Load(191, 1019), 199
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 194
+			}
+		case 194: // OUT  [187]
+			r.Out(194, r.Load(194, 187))
+			pc = 196
+		case 196: // JF   #0, #203
+			value, target := 0, 203
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 199
+			}
+		case 199: // ADD  [64], #1, [64]
+			pc = 203
+			if r.Store(199, 64, r.Load(199, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 203: // MUL  [64], #2, [64]
+			pc = 207
+			if r.Store(203, 64, r.Load(203, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 207: // ARB  #-30
+			rb += int(-30)
+			pc = 209
+		case 209: // LT   #38, rb+7, [63]
+			flag := int64(0)
+			if 38 < r.Load(209, int64(rb)+7) {
+				flag = 1
+			}
+			pc = 213
+			if r.Store(209, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 213: // JT   [63], #221
+			value, target := r.Load(213, 63), 221
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 216
+			}
+		case 216: // OUT  [209]
+			r.Out(216, r.Load(216, 209))
+			pc = 218
+		case 218: // JT   #1, #225
+			value, target := 1, 225
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 221
+			}
+		case 221: // ADD  [64], #1, [64]
+			pc = 225
+			if r.Store(221, 64, r.Load(221, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 225: // MUL  [64], #2, [64]
+			pc = 229
+			if r.Store(225, 64, r.Load(225, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 229: // ARB  #-5
+			rb += int(-5)
+			pc = 231
+		case 231: // MUL  #1, rb+8, [63]
+			pc = 235
+			if r.Store(231, 63, 1*r.Load(231, int64(rb)+8)) {
+				return r.Interpret(pc, rb)
+			}
+		case 235: // EQ   [63], #21, [63]
+			flag := int64(0)
+			if r.Load(235, 63) == 21 {
+				flag = 1
+			}
+			pc = 239
+			if r.Store(235, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 239: // JT   [63], #251
+			value, target := r.Load(239, 63), 251
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 242
+			}
+		case 242: // OUT  [231]
+			r.Out(242, r.Load(242, 231))
+			pc = 244
+		case 244: // ADD  [64], #1, [64]
+			pc = 248
+			if r.Store(244, 64, r.Load(244, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 248: // JF   #0, #251
+			value, target := 0, 251
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 251
+			}
+		case 251: // MUL  [64], #2, [64]
+			pc = 255
+			if r.Store(251, 64, r.Load(251, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 255: // ARB  #21
+			rb += int(21)
+			pc = 257
+		case 257: // LT   rb-7, #21, [63]
+			flag := int64(0)
+			if r.Load(257, int64(rb)+-7) < 21 {
+				flag = 1
+			}
+			pc = 261
+			if r.Store(257, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 261: // JT   [63], #267
+			value, target := r.Load(261, 63), 267
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 264
+			}
+		case 264: // JT   #1, #273
+			value, target := 1, 273
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 267
+			}
+		case 267: // OUT  [257]
+			r.Out(267, r.Load(267, 257))
+			pc = 269
+		case 269: // ADD  [64], #1, [64]
+			pc = 273
+			if r.Store(269, 64, r.Load(269, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 273: // MUL  [64], #2, [64]
+			pc = 277
+			if r.Store(273, 64, r.Load(273, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 277: // ARB  #-1
+			rb += int(-1)
+			pc = 279
+		case 279: // ADD  rb-7, #0, [63]
+			pc = 283
+			if r.Store(279, 63, r.Load(279, int64(rb)+-7)+0) {
+				return r.Interpret(pc, rb)
+			}
+		case 283: // EQ   [63], #29, [63]
+			flag := int64(0)
+			if r.Load(283, 63) == 29 {
+				flag = 1
+			}
+			pc = 287
+			if r.Store(283, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 287: // JT   [63], #293
+			value, target := r.Load(287, 63), 293
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 290
+			}
+		case 290: // JF   #0, #299
+			value, target := 0, 299
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 293
+			}
+		case 293: // OUT  [279]
+			r.Out(293, r.Load(293, 279))
+			pc = 295
+		case 295: // ADD  [64], #1, [64]
+			pc = 299
+			if r.Store(295, 64, r.Load(295, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 299: // MUL  [64], #2, [64]
+			pc = 303
+			if r.Store(299, 64, r.Load(299, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 303: // ARB  #-4
+			rb += int(-4)
+			pc = 305
+		case 305: // MUL  rb-3, #1, [63]
+			pc = 309
+			if r.Store(305, 63, r.Load(305, int64(rb)+-3)*1) {
+				return r.Interpret(pc, rb)
+			}
+		case 309: // EQ   [63], #28, [63]
+			flag := int64(0)
+			if r.Load(309, 63) == 28 {
+				flag = 1
+			}
+			pc = 313
+			if r.Store(309, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 313: // JT   [63], #319
+			value, target := r.Load(313, 63), 319
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 316
+			}
+		case 316: // JF   #0, #325
+			value, target := 0, 325
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 319
+			}
+		case 319: // OUT  [305]
+			r.Out(319, r.Load(319, 305))
+			pc = 321
+		case 321: // ADD  [64], #1, [64]
+			pc = 325
+			if r.Store(321, 64, r.Load(321, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 325: // MUL  [64], #2, [64]
+			pc = 329
+			if r.Store(325, 64, r.Load(325, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 329: // ARB  #14
+			rb += int(14)
+			pc = 331
+		case 331: // JF   rb-3, #343
+			value, target := r.Load(331, int64(rb)+-3), 343
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 334
+			}
+		case 334: // OUT  [331]
+			r.Out(334, r.Load(334, 331))
+			pc = 336
+		case 336: // ADD  [64], #1, [64]
+			pc = 340
+			if r.Store(336, 64, r.Load(336, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 340: // JF   #0, #343
+			value, target := 0, 343
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 343
+			}
+		case 343: // MUL  [64], #2, [64]
+			pc = 347
+			if r.Store(343, 64, r.Load(343, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 347: // ARB  #-14
+			rb += int(-14)
+			pc = 349
+		case 349: // EQ   #21, rb-8, [63]
+			flag := int64(0)
+			if 21 == r.Load(349, int64(rb)+-8) {
+				flag = 1
+			}
+			pc = 353
+			if r.Store(349, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 353: // JT   [63], #361
+			value, target := r.Load(353, 63), 361
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 356
+			}
+		case 356: // OUT  [349]
+			r.Out(356, r.Load(356, 349))
+			pc = 358
+		case 358: // JT   #1, #365
+			value, target := 1, 365
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 361
+			}
+		case 361: // ADD  [64], #1, [64]
+			pc = 365
+			if r.Store(361, 64, r.Load(361, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 365: // MUL  [64], #2, [64]
+			pc = 369
+			if r.Store(365, 64, r.Load(365, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 369: // ARB  #-9
+			rb += int(-9)
+			pc = 371
+		case 371: // ADD  rb+9, #0, [63]
+			pc = 375
+			if r.Store(371, 63, r.Load(371, int64(rb)+9)+0) {
+				return r.Interpret(pc, rb)
+			}
+		case 375: // EQ   [63], #27, [63]
+			flag := int64(0)
+			if r.Load(375, 63) == 27 {
+				flag = 1
+			}
+			pc = 379
+			if r.Store(375, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 379: // JT   [63], #391
+			value, target := r.Load(379, 63), 391
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 382
+			}
+		case 382: // OUT  [371]
+			r.Out(382, r.Load(382, 371))
+			pc = 384
+		case 384: // ADD  [64], #1, [64]
+			pc = 388
+			if r.Store(384, 64, r.Load(384, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 388: // JF   #0, #391
+			value, target := 0, 391
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 391
+			}
+		case 391: // MUL  [64], #2, [64]
+			pc = 395
+			if r.Store(391, 64, r.Load(391, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 395: // ARB  #27
+			rb += int(27)
+			pc = 397
+		case 397: // JF   #0, rb+0
+			value, target := 0, r.Load(397, int64(rb)+0)
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 400
+			}
+		case 400: // JF   #0, #409
+			value, target := 0, 409
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 403
+			}
+		case 403: // OUT  [397]
+			r.Out(403, r.Load(403, 397))
+			pc = 405
+		case 405: // ADD  [64], #1, [64]
+			pc = 409
+			if r.Store(405, 64, r.Load(405, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 409: // MUL  [64], #2, [64]
+			pc = 413
+			if r.Store(409, 64, r.Load(409, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 413: // ARB  #-20
+			rb += int(-20)
+			pc = 415
+		case 415: // ADD  #0, rb+0, [63]
+			pc = 419
+			if r.Store(415, 63, 0+r.Load(415, int64(rb)+0)) {
+				return r.Interpret(pc, rb)
+			}
+		case 419: // EQ   [63], #22, [63]
+			flag := int64(0)
+			if r.Load(419, 63) == 22 {
+				flag = 1
+			}
+			pc = 423
+			if r.Store(419, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 423: // JT   [63], #431
+			value, target := r.Load(423, 63), 431
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 426
+			}
+		case 426: // OUT  [415]
+			r.Out(426, r.Load(426, 415))
+			pc = 428
+		case 428: // JT   #1, #435
+			value, target := 1, 435
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 431
+			}
+		case 431: // ADD  [64], #1, [64]
+			pc = 435
+			if r.Store(431, 64, r.Load(431, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 435: // MUL  [64], #2, [64]
+			pc = 439
+			if r.Store(435, 64, r.Load(435, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 439: // ARB  #-7
+			rb += int(-7)
+			pc = 441
+		case 441: // MUL  rb+7, #1, [63]
+			pc = 445
+			if r.Store(441, 63, r.Load(441, int64(rb)+7)*1) {
+				return r.Interpret(pc, rb)
+			}
+		case 445: // EQ   [63], #22, [63]
+			flag := int64(0)
+			if r.Load(445, 63) == 22 {
+				flag = 1
+			}
+			pc = 449
+			if r.Store(445, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 449: // JT   [63], #457
+			value, target := r.Load(449, 63), 457
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 452
+			}
+		case 452: // OUT  [441]
+			r.Out(452, r.Load(452, 441))
+			pc = 454
+		case 454: // JT   #1, #461
+			value, target := 1, 461
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 457
+			}
+		case 457: // ADD  [64], #1, [64]
+			pc = 461
+			if r.Store(457, 64, r.Load(457, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 461: // MUL  [64], #2, [64]
+			pc = 465
+			if r.Store(461, 64, r.Load(461, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 465: // ARB  #8
+			rb += int(8)
+			pc = 467
+		case 467: // EQ   rb+0, #23, [63]
+			flag := int64(0)
+			if r.Load(467, int64(rb)+0) == 23 {
+				flag = 1
+			}
+			pc = 471
+			if r.Store(467, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 471: // JT   [63], #479
+			value, target := r.Load(471, 63), 479
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 474
+			}
+		case 474: // OUT  [467]
+			r.Out(474, r.Load(474, 467))
+			pc = 476
+		case 476: // JF   #0, #483
+			value, target := 0, 483
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 479
+			}
+		case 479: // ADD  [64], #1, [64]
+			pc = 483
+			if r.Store(479, 64, r.Load(479, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 483: // MUL  [64], #2, [64]
+			pc = 487
+			if r.Store(483, 64, r.Load(483, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 487: // ARB  #20
+			rb += int(20)
+			pc = 489
+		case 489: // JT   rb-8, #495
+			value, target := r.Load(489, int64(rb)+-8), 495
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 492
+			}
+		case 492: // JT   #1, #501
+			value, target := 1, 501
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 495
+			}
+		case 495: // OUT  [489]
+			r.Out(495, r.Load(495, 489))
+			pc = 497
+		case 497: // ADD  [64], #1, [64]
+			pc = 501
+			if r.Store(497, 64, r.Load(497, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 501: // MUL  [64], #2, [64]
+			pc = 505
+			if r.Store(501, 64, r.Load(501, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 505: // ARB  #-26
+			rb += int(-26)
+			pc = 507
+		case 507: // EQ   rb+4, #28, [63]
+			flag := int64(0)
+			if r.Load(507, int64(rb)+4) == 28 {
+				flag = 1
+			}
+			pc = 511
+			if r.Store(507, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 511: // JT   [63], #521
+			value, target := r.Load(511, 63), 521
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 514
+			}
+		case 514: // ADD  [64], #1, [64]
+			pc = 518
+			if r.Store(514, 64, r.Load(514, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 518: // JT   #1, #523
+			value, target := 1, 523
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 521
+			}
+		case 521: // OUT  [507]
+			r.Out(521, r.Load(521, 507))
+			pc = 523
+		case 523: // MUL  [64], #2, [64]
+			pc = 527
+			if r.Store(523, 64, r.Load(523, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 527: // ARB  #15
+			rb += int(15)
+			pc = 529
+		case 529: // MUL  #41, #1, rb-2
+			pc = 533
+			if r.Store(529, int64(rb)+-2, 41*1) {
+				return r.Interpret(pc, rb)
+			}
+		case 533: // EQ   [1015], #41, [63]
+			flag := int64(0)
+			if r.Load(533, 1015) == 41 {
+				flag = 1
+			}
+			pc = 537
+			if r.Store(533, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 537: // JT   [63], #545
+			value, target := r.Load(537, 63), 545
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 540
+			}
+		case 540: // OUT  [529]
+			r.Out(540, r.Load(540, 529))
+			pc = 542
+		case 542: // JF   #0, #549
+			value, target := 0, 549
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 545
+			}
+		case 545: // ADD  [64], #1, [64]
+			pc = 549
+			if r.Store(545, 64, r.Load(545, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 549: // MUL  [64], #2, [64]
+			pc = 553
+			if r.Store(549, 64, r.Load(549, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 553: // ARB  #18
+			rb += int(18)
+			pc = 555
+		case 555: // JF   #0, rb-7
+			value, target := 0, r.Load(555, int64(rb)+-7)
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 558
+			}
+		case 558: // OUT  [555]
+			r.Out(558, r.Load(558, 555))
+			pc = 560
+		case 560: // ADD  [64], #1, [64]
+			pc = 564
+			if r.Store(560, 64, r.Load(560, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 564: // JF   #0, #567
+			value, target := 0, 567
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 567
+			}
+		case 567: // MUL  [64], #2, [64]
+			pc = 571
+			if r.Store(567, 64, r.Load(567, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 571: // ARB  #-30
+			rb += int(-30)
+			pc = 573
+		case 573: // LT   rb-3, #35, [63]
+			flag := int64(0)
+			if r.Load(573, int64(rb)+-3) < 35 {
+				flag = 1
+			}
+			pc = 577
+			if r.Store(573, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 577: // JT   [63], #585
+			value, target := r.Load(577, 63), 585
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 580
+			}
+		case 580: // OUT  [573]
+			r.Out(580, r.Load(580, 573))
+			pc = 582
+		case 582: // JT   #1, #589
+			value, target := 1, 589
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 585
+			}
+		case 585: // ADD  [64], #1, [64]
+			pc = 589
+			if r.Store(585, 64, r.Load(585, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 589: // MUL  [64], #2, [64]
+			pc = 593
+			if r.Store(589, 64, r.Load(589, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 593: // ARB  #14
+			rb += int(14)
+			pc = 595
+		case 595: // JF   rb+2, #605
+			value, target := r.Load(595, int64(rb)+2), 605
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 598
+			}
+		case 598: // ADD  [64], #1, [64]
+			pc = 602
+			if r.Store(598, 64, r.Load(598, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 602: // JF   #0, #607
+			value, target := 0, 607
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 605
+			}
+		case 605: // OUT  [595]
+			r.Out(605, r.Load(605, 595))
+			pc = 607
+		case 607: // MUL  [64], #2, [64]
+			pc = 611
+			if r.Store(607, 64, r.Load(607, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 611: // ARB  #-3
+			rb += int(-3)
+			pc = 613
+		case 613: // JT   rb+5, #621
+			value, target := r.Load(613, int64(rb)+5), 621
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 616
+			}
+		case 616: // OUT  [613]
+			r.Out(616, r.Load(616, 613))
+			pc = 618
+		case 618: // JF   #0, #625
+			value, target := 0, 625
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 621
+			}
+		case 621: // ADD  [64], #1, [64]
+			pc = 625
+			if r.Store(621, 64, r.Load(621, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 625: // MUL  [64], #2, [64]
+			pc = 629
+			if r.Store(625, 64, r.Load(625, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 629: // ARB  #-5
+			rb += int(-5)
+			pc = 631
+		case 631: // LT   #42, #41, rb+2
+			flag := int64(0)
+			if 42 < 41 {
+				flag = 1
+			}
+			pc = 635
+			if r.Store(631, int64(rb)+2, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 635: // JT   [1013], #645
+			value, target := r.Load(635, 1013), 645
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 638
+			}
+		case 638: // ADD  [64], #1, [64]
+			pc = 642
+			if r.Store(638, 64, r.Load(638, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 642: // JF   #0, #647
+			value, target := 0, 647
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 645
+			}
+		case 645: // OUT  [631]
+			r.Out(645, r.Load(645, 631))
+			pc = 647
+		case 647: // MUL  [64], #2, [64]
+			pc = 651
+			if r.Store(647, 64, r.Load(647, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 651: // ARB  #-11
+			rb += int(-11)
+			pc = 653
+		case 653: // EQ   #42, rb+5, [63]
+			flag := int64(0)
+			if 42 == r.Load(653, int64(rb)+5) {
+				flag = 1
+			}
+			pc = 657
+			if r.Store(653, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 657: // JT   [63], #663
+			value, target := r.Load(657, 63), 663
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 660
+			}
+		case 660: // JF   #0, #669
+			value, target := 0, 669
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 663
+			}
+		case 663: // OUT  [653]
+			r.Out(663, r.Load(663, 653))
+			pc = 665
+		case 665: // ADD  [64], #1, [64]
+			pc = 669
+			if r.Store(665, 64, r.Load(665, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 669: // MUL  [64], #2, [64]
+			pc = 673
+			if r.Store(669, 64, r.Load(669, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 673: // ARB  #4
+			rb += int(4)
+			pc = 675
+		case 675: // MUL  #43, #1, rb+9
+			pc = 679
+			if r.Store(675, int64(rb)+9, 43*1) {
+				return r.Interpret(pc, rb)
+			}
+		case 679: // EQ   [1013], #40, [63]
+			flag := int64(0)
+			if r.Load(679, 1013) == 40 {
+				flag = 1
+			}
+			pc = 683
+			if r.Store(679, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 683: // JT   [63], #693
+			value, target := r.Load(683, 63), 693
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 686
+			}
+		case 686: // ADD  [64], #1, [64]
+			pc = 690
+			if r.Store(686, 64, r.Load(686, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 690: // JF   #0, #695
+			value, target := 0, 695
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 693
+			}
+		case 693: // OUT  [675]
+			r.Out(693, r.Load(693, 675))
+			pc = 695
+		case 695: // MUL  [64], #2, [64]
+			pc = 699
+			if r.Store(695, 64, r.Load(695, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 699: // ARB  #-1
+			rb += int(-1)
+			pc = 701
+		case 701: // LT   #22, rb-2, [63]
+			flag := int64(0)
+			if 22 < r.Load(701, int64(rb)+-2) {
+				flag = 1
+			}
+			pc = 705
+			if r.Store(701, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 705: // JT   [63], #715
+			value, target := r.Load(705, 63), 715
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 708
+			}
+		case 708: // ADD  [64], #1, [64]
+			pc = 712
+			if r.Store(708, 64, r.Load(708, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 712: // JF   #0, #717
+			value, target := 0, 717
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 715
+			}
+		case 715: // OUT  [701]
+			r.Out(715, r.Load(715, 701))
+			pc = 717
+		case 717: // MUL  [64], #2, [64]
+			pc = 721
+			if r.Store(717, 64, r.Load(717, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 721: // ARB  #7
+			rb += int(7)
+			pc = 723
+		case 723: // ADD  #44, #0, rb+0
+			pc = 727
+			if r.Store(723, int64(rb)+0, 44+0) {
+				return r.Interpret(pc, rb)
+			}
+		case 727: // EQ   [1010], #45, [63]
+			flag := int64(0)
+			if r.Load(727, 1010) == 45 {
+				flag = 1
+			}
+			pc = 731
+			if r.Store(727, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 731: // JT   [63], #741
+			value, target := r.Load(731, 63), 741
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 734
+			}
+		case 734: // ADD  [64], #1, [64]
+			pc = 738
+			if r.Store(734, 64, r.Load(734, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 738: // JF   #0, #743
+			value, target := 0, 743
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 741
+			}
+		case 741: // OUT  [723]
+			r.Out(741, r.Load(741, 723))
+			pc = 743
+		case 743: // MUL  [64], #2, [64]
+			pc = 747
+			if r.Store(743, 64, r.Load(743, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 747: // ARB  #9
+			rb += int(9)
+			pc = 749
+		case 749: // JT   #1, rb+4
+			value, target := 1, r.Load(749, int64(rb)+4)
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 752
+			}
+		case 752: // JF   #0, #761
+			value, target := 0, 761
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 755
+			}
+		case 755: // OUT  [749]
+			r.Out(755, r.Load(755, 749))
+			pc = 757
+		case 757: // ADD  [64], #1, [64]
+			pc = 761
+			if r.Store(757, 64, r.Load(757, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 761: // MUL  [64], #2, [64]
+			pc = 765
+			if r.Store(761, 64, r.Load(761, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 765: // ARB  #10
+			rb += int(10)
+			pc = 767
+		case 767: // JT   #1, rb-5
+			value, target := 1, r.Load(767, int64(rb)+-5)
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 770
+			}
+		case 770: // OUT  [767]
+			r.Out(770, r.Load(770, 767))
+			pc = 772
+		case 772: // ADD  [64], #1, [64]
+			pc = 776
+			if r.Store(772, 64, r.Load(772, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 776: // JT   #1, #779
+			value, target := 1, 779
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 779
+			}
+		case 779: // MUL  [64], #2, [64]
+			pc = 783
+			if r.Store(779, 64, r.Load(779, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 783: // ARB  #-22
+			rb += int(-22)
+			pc = 785
+		case 785: // EQ   #45, #43, rb+10
+			flag := int64(0)
+			if 45 == 43 {
+				flag = 1
+			}
+			pc = 789
+			if r.Store(785, int64(rb)+10, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 789: // JT   [1017], #799
+			value, target := r.Load(789, 1017), 799
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 792
+			}
+		case 792: // ADD  [64], #1, [64]
+			pc = 796
+			if r.Store(792, 64, r.Load(792, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 796: // JT   #1, #801
+			value, target := 1, 801
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 799
+			}
+		case 799: // OUT  [785]
+			r.Out(799, r.Load(799, 785))
+			pc = 801
+		case 801: // MUL  [64], #2, [64]
+			pc = 805
+			if r.Store(801, 64, r.Load(801, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 805: // ARB  #16
+			rb += int(16)
+			pc = 807
+		case 807: // ADD  #46, #0, rb-8
+			pc = 811
+			if r.Store(807, int64(rb)+-8, 46+0) {
+				return r.Interpret(pc, rb)
+			}
+		case 811: // EQ   [1015], #46, [63]
+			flag := int64(0)
+			if r.Load(811, 1015) == 46 {
+				flag = 1
+			}
+			pc = 815
+			if r.Store(811, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 815: // JT   [63], #827
+			value, target := r.Load(815, 63), 827
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 818
+			}
+		case 818: // OUT  [807]
+			r.Out(818, r.Load(818, 807))
+			pc = 820
+		case 820: // ADD  [64], #1, [64]
+			pc = 824
+			if r.Store(820, 64, r.Load(820, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 824: // JT   #1, #827
+			value, target := 1, 827
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 827
+			}
+		case 827: // MUL  [64], #2, [64]
+			pc = 831
+			if r.Store(827, 64, r.Load(827, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 831: // ARB  #-7
+			rb += int(-7)
+			pc = 833
+		case 833: // ADD  #0, rb-7, [63]
+			pc = 837
+			if r.Store(833, 63, 0+r.Load(833, int64(rb)+-7)) {
+				return r.Interpret(pc, rb)
+			}
+		case 837: // EQ   [63], #29, [63]
+			flag := int64(0)
+			if r.Load(837, 63) == 29 {
+				flag = 1
+			}
+			pc = 841
+			if r.Store(837, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 841: // JT   [63], #851
+			value, target := r.Load(841, 63), 851
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 844
+			}
+		case 844: // ADD  [64], #1, [64]
+			pc = 848
+			if r.Store(844, 64, r.Load(844, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 848: // JF   #0, #853
+			value, target := 0, 853
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 851
+			}
+		case 851: // OUT  [833]
+			r.Out(851, r.Load(851, 833))
+			pc = 853
+		case 853: // MUL  [64], #2, [64]
+			pc = 857
+			if r.Store(853, 64, r.Load(853, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 857: // ARB  #-5
+			rb += int(-5)
+			pc = 859
+		case 859: // MUL  #1, rb-3, [63]
+			pc = 863
+			if r.Store(859, 63, 1*r.Load(859, int64(rb)+-3)) {
+				return r.Interpret(pc, rb)
+			}
+		case 863: // EQ   [63], #22, [63]
+			flag := int64(0)
+			if r.Load(863, 63) == 22 {
+				flag = 1
+			}
+			pc = 867
+			if r.Store(863, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 867: // JT   [63], #877
+			value, target := r.Load(867, 63), 877
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 870
+			}
+		case 870: // ADD  [64], #1, [64]
+			pc = 874
+			if r.Store(870, 64, r.Load(870, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 874: // JF   #0, #879
+			value, target := 0, 879
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 877
+			}
+		case 877: // OUT  [859]
+			r.Out(877, r.Load(877, 859))
+			pc = 879
+		case 879: // MUL  [64], #2, [64]
+			pc = 883
+			if r.Store(879, 64, r.Load(879, 64)*2) {
+				return r.Interpret(pc, rb)
+			}
+		case 883: // ARB  #9
+			rb += int(9)
+			pc = 885
+		case 885: // EQ   #47, #47, rb-5
+			flag := int64(0)
+			if 47 == 47 {
+				flag = 1
+			}
+			pc = 889
+			if r.Store(885, int64(rb)+-5, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 889: // JT   [1015], #897
+			value, target := r.Load(889, 1015), 897
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 892
+			}
+		case 892: // OUT  [885]
+			r.Out(892, r.Load(892, 885))
+			pc = 894
+		case 894: // JT   #1, #901
+			value, target := 1, 901
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 897
+			}
+		case 897: // ADD  [64], #1, [64]
+			pc = 901
+			if r.Store(897, 64, r.Load(897, 64)+1) {
+				return r.Interpret(pc, rb)
+			}
+		case 901: // OUT  [64]
+			r.Out(901, r.Load(901, 64))
+			pc = 903
+		case 903: // HLT
+			return nil
+		case 904: // MUL  #27, #1, rb+1
+			pc = 908
+			if r.Store(904, int64(rb)+1, 27*1) {
+				return r.Interpret(pc, rb)
+			}
+		case 908: // ADD  #0, #915, rb+0
+			pc = 912
+			if r.Store(908, int64(rb)+0, 0+915) {
+				return r.Interpret(pc, rb)
+			}
+		case 912: // JT   #1, #922
+			value, target := 1, 922
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 915
+			}
+		case 915: // ADD  rb+1, #61784, rb+1
+			pc = 919
+			if r.Store(915, int64(rb)+1, r.Load(915, int64(rb)+1)+61784) {
+				return r.Interpret(pc, rb)
+			}
+		case 919: // OUT  rb+1
+			r.Out(919, r.Load(919, int64(rb)+1))
+			pc = 921
+		case 921: // HLT
+			return nil
+		case 922: // ARB  #3
+			rb += int(3)
+			pc = 924
+		case 924: // LT   rb-2, #3, [63]
+			flag := int64(0)
+			if r.Load(924, int64(rb)+-2) < 3 {
+				flag = 1
+			}
+			pc = 928
+			if r.Store(924, 63, flag) {
+				return r.Interpret(pc, rb)
+			}
+		case 928: // JT   [63], #964
+			value, target := r.Load(928, 63), 964
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 931
+			}
+		case 931: // ADD  rb-2, #-1, rb+1
+			pc = 935
+			if r.Store(931, int64(rb)+1, r.Load(931, int64(rb)+-2)+-1) {
+				return r.Interpret(pc, rb)
+			}
+		case 935: // ADD  #942, #0, rb+0
+			pc = 939
+			if r.Store(935, int64(rb)+0, 942+0) {
+				return r.Interpret(pc, rb)
+			}
+		case 939: // JT   #1, #922
+			value, target := 1, 922
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 942
+			}
+		case 942: // MUL  #1, rb+1, rb-1
+			pc = 946
+			if r.Store(942, int64(rb)+-1, 1*r.Load(942, int64(rb)+1)) {
+				return r.Interpret(pc, rb)
+			}
+		case 946: // ADD  rb-2, #-3, rb+1
+			pc = 950
+			if r.Store(946, int64(rb)+1, r.Load(946, int64(rb)+-2)+-3) {
+				return r.Interpret(pc, rb)
+			}
+		case 950: // MUL  #1, #957, rb+0
+			pc = 954
+			if r.Store(950, int64(rb)+0, 1*957) {
+				return r.Interpret(pc, rb)
+			}
+		case 954: // JF   #0, #922
+			value, target := 0, 922
+			if value == 0 {
+				pc = int(target)
+			} else {
+				pc = 957
+			}
+		case 957: // ADD  rb+1, rb-1, rb-2
+			pc = 961
+			if r.Store(957, int64(rb)+-2, r.Load(957, int64(rb)+1)+r.Load(957, int64(rb)+-1)) {
+				return r.Interpret(pc, rb)
+			}
+		case 961: // JT   #1, #968
+			value, target := 1, 968
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 964
+			}
+		case 964: // ADD  #0, rb-2, rb-2
+			pc = 968
+			if r.Store(964, int64(rb)+-2, 0+r.Load(964, int64(rb)+-2)) {
+				return r.Interpret(pc, rb)
+			}
+		case 968: // ARB  #-3
+			rb += int(-3)
+			pc = 970
+		case 970: // JT   #1, rb+0
+			value, target := 1, r.Load(970, int64(rb)+0)
+			if value != 0 {
+				pc = int(target)
+			} else {
+				pc = 973
+			}
+		default:
+			var done bool
+			if pc, rb, done = r.Step(pc, rb); done {
+				return nil
+			}
+			if r.Modified {
+				return r.Interpret(pc, rb)
+			}
+		}
+	}
+}
@@ -0,0 +1,7 @@
+// Package native holds the day programs transpiled to Go (see intcode.Transpile), they are compared with
+// the interpreter and benchmarked by the tests of the intcode package.
+package native
+
+//go:generate go run ../../cmd/transpile -package day5 -o day5/day5.go ../../../5/code
+//go:generate go run ../../cmd/transpile -package day7 -o day7/day7.go ../../../7/code
+//go:generate go run ../../cmd/transpile -package day9 -o day9/day9.go ../../../9/code
@@ -0,0 +1,248 @@
+package intcode
+
+import (
+    "bufio"
+    "context"
+    "errors"
+    "fmt"
+    "io"
+    "math/big"
+    "strconv"
+)
+
+// ErrNoInput is returned by an Input that has no more values to provide
+var ErrNoInput = errors.New("no input available")
+
+// Input supplies values to the Read instructions of a Program
+type Input interface {
+    ReadInput(ctx context.Context) (int64, error)
+}
+
+// Output receives values of the Write instructions of a Program
+type Output interface {
+    WriteOutput(ctx context.Context, value int64) error
+}
+
+// InputFunc adapts a function to the Input interface
+type InputFunc func(ctx context.Context) (int64, error)
+
+func (f InputFunc) ReadInput(ctx context.Context) (int64, error) {
+    return f(ctx)
+}
+
+// OutputFunc adapts a function to the Output interface
+type OutputFunc func(ctx context.Context, value int64) error
+
+func (f OutputFunc) WriteOutput(ctx context.Context, value int64) error {
+    return f(ctx, value)
+}
+
+// QueueInput provides values in the order they were pushed
+type QueueInput struct {
+    values []int64
+}
+
+func NewQueueInput(values ...int64) *QueueInput {
+    return &QueueInput{values: append([]int64(nil), values...)}
+}
+
+func (q *QueueInput) Push(values ...int64) {
+    q.values = append(q.values, values...)
+}
+
+// Len returns number of values waiting in the queue
+func (q *QueueInput) Len() int {
+    return len(q.values)
+}
+
+// Pending returns copy of the values waiting in the queue
+func (q *QueueInput) Pending() []int64 {
+    return append([]int64(nil), q.values...)
+}
+
+func (q *QueueInput) setPending(values []int64) {
+    q.values = append([]int64(nil), values...)
+}
+
+func (q *QueueInput) ReadInput(ctx context.Context) (int64, error) {
+    if len(q.values) == 0 {
+        return 0, ErrNoInput
+    }
+
+    value := q.values[0]
+    q.values = q.values[1:]
+    return value, nil
+}
+
+// SliceOutput collects all the values in the order they were written
+type SliceOutput struct {
+    Values []int64
+}
+
+func (s *SliceOutput) WriteOutput(ctx context.Context, value int64) error {
+    s.Values = append(s.Values, value)
+    return nil
+}
+
+// Last returns the most recently written value (0 if there is none)
+func (s *SliceOutput) Last() int64 {
+    if len(s.Values) == 0 {
+        return 0
+    }
+    return s.Values[len(s.Values)-1]
+}
+
+// ChannelInput receives values from the channel, closed channel means there is no more input
+type ChannelInput <-chan int64
+
+func (c ChannelInput) ReadInput(ctx context.Context) (int64, error) {
+    select {
+    case value, ok := <-c:
+        if !ok {
+            return 0, ErrNoInput
+        }
+        return value, nil
+    case <-ctx.Done():
+        return 0, ctx.Err()
+    }
+}
+
+// ChannelOutput sends values to the channel
+type ChannelOutput chan<- int64
+
+func (c ChannelOutput) WriteOutput(ctx context.Context, value int64) error {
+    select {
+    case c <- value:
+        return nil
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+}
+
+// ReaderInput parses values separated by white space or commas from the reader. When PromptWriter is set,
+// Prompt is written there before every value is read. Waiting for the reader ends when the context is done,
+// the value read afterwards is returned by the next ReadInput.
+type ReaderInput struct {
+    Prompt       string
+    PromptWriter io.Writer
+
+    scanner *bufio.Scanner
+    read    backgroundRead
+}
+
+func NewReaderInput(r io.Reader) *ReaderInput {
+    scanner := bufio.NewScanner(r)
+    scanner.Split(scanValues)
+
+    return &ReaderInput{scanner: scanner}
+}
+
+func (r *ReaderInput) ReadInput(ctx context.Context) (int64, error) {
+    if r.PromptWriter != nil && !r.read.started() {
+        fmt.Fprint(r.PromptWriter, r.Prompt)
+    }
+
+    text, err := r.read.wait(ctx, func() (string, error) {
+        if !r.scanner.Scan() {
+            if err := r.scanner.Err(); err != nil {
+                return "", err
+            }
+            return "", ErrNoInput
+        }
+        return r.scanner.Text(), nil
+    })
+    if err != nil {
+        return 0, err
+    }
+
+    return strconv.ParseInt(text, 10, 64)
+}
+
+// Read from a reader running in the background, so waiting for it can be abandoned when the context is done. Blocked
+// read can not be interrupted, its result is kept for the next wait.
+type backgroundRead struct {
+    result chan readResult
+}
+
+type readResult struct {
+    text string
+    err  error
+}
+
+// Reports whether the read has started and its result has not been received yet
+func (b *backgroundRead) started() bool {
+    return b.result != nil
+}
+
+// Waits for the result of the read, the read function is started only when there is no read in progress
+func (b *backgroundRead) wait(ctx context.Context, read func() (string, error)) (string, error) {
+    if b.result == nil {
+        result := make(chan readResult, 1)
+        go func() {
+            text, err := read()
+            result <- readResult{text, err}
+        }()
+        b.result = result
+    }
+
+    select {
+    case result := <-b.result:
+        b.result = nil
+        return result.text, result.err
+    case <-ctx.Done():
+        return "", ctx.Err()
+    }
+}
+
+// Split function for bufio.Scanner that returns values separated by white space or commas
+func scanValues(data []byte, atEOF bool) (int, []byte, error) {
+    start := 0
+    for start < len(data) && isValueSeparator(data[start]) {
+        start++
+    }
+
+    for end := start; end < len(data); end++ {
+        if isValueSeparator(data[end]) {
+            return end + 1, data[start:end], nil
+        }
+    }
+
+    if atEOF && start < len(data) {
+        return len(data), data[start:], nil
+    }
+
+    return start, nil, nil
+}
+
+func isValueSeparator(b byte) bool {
+    return b == ' ' || b == ',' || b == '\n' || b == '\r' || b == '\t'
+}
+
+// WriterOutput writes every value on a separate line, preceded by the Prefix
+type WriterOutput struct {
+    Prefix string
+
+    writer io.Writer
+}
+
+func NewWriterOutput(w io.Writer) *WriterOutput {
+    return &WriterOutput{writer: w}
+}
+
+func (w *WriterOutput) WriteOutput(ctx context.Context, value int64) error {
+    _, err := fmt.Fprintf(w.writer, "%s%d\n", w.Prefix, value)
+    return err
+}
+
+func (w *WriterOutput) WriteBigOutput(ctx context.Context, value *big.Int) error {
+    _, err := fmt.Fprintf(w.writer, "%s%s\n", w.Prefix, value)
+    return err
+}
+
+// Output of the program logging values to Standard Output, used when its Output is not set
+type programOutput struct{}
+
+func (programOutput) WriteOutput(ctx context.Context, value int64) error {
+    fmt.Println("Program outputs: ", value)
+    return nil
+}
@@ -0,0 +1,75 @@
+package intcode
+
+import (
+    "context"
+    "errors"
+    "io"
+    "testing"
+    "time"
+)
+
+// Outputs of the program without Output are never read back as its inputs
+func TestPushedInputIsNotOutput(t *testing.T) {
+    code, err := Assemble(`
+        OUT  #5
+        IN   [v]
+        HLT
+    v:  data 0
+    `)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    p := &Program{Quiet: true}
+    p.LoadCode(code)
+    var starved *InputStarvedError
+    if err := p.Execute(); !errors.As(err, &starved) {
+        t.Fatalf("expected starved input, got %v", err)
+    }
+
+    p.LoadCode(code)
+    p.Completed = false
+    p.Position = 0
+    p.PushInput(7)
+    if err := p.Execute(); err != nil {
+        t.Fatal(err)
+    }
+    if value, _ := p.ReadMemory(5); value != 7 {
+        t.Errorf("read %d, expected 7", value)
+    }
+}
+
+func TestReaderInputContext(t *testing.T) {
+    reader, writer := io.Pipe()
+    defer writer.Close()
+    tests := []struct {
+        name  string
+        input Input
+        value int64
+    }{
+        {"values", NewReaderInput(reader), 42},
+        {"ascii", NewASCIIReaderInput(reader), '4'},
+    }
+
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            ctx, cancel := context.WithTimeout(context.Background(), 20 * time.Millisecond)
+            defer cancel()
+            if _, err := test.input.ReadInput(ctx); !errors.Is(err, context.DeadlineExceeded) {
+                t.Fatalf("expected deadline exceeded, got %v", err)
+            }
+
+            // The value read after the context was done is returned by the next read
+            if _, err := io.WriteString(writer, "42\n"); err != nil {
+                t.Fatal(err)
+            }
+            value, err := test.input.ReadInput(context.Background())
+            if err != nil {
+                t.Fatal(err)
+            }
+            if value != test.value {
+                t.Errorf("read %d, expected %d", value, test.value)
+            }
+        })
+    }
+}
@@ -0,0 +1,213 @@
+package intcode
+
+import (
+    "errors"
+    "fmt"
+    "math/big"
+)
+
+// ErrJournalExhausted is returned when the program should step back beyond the oldest step of its journal
+var ErrJournalExhausted = errors.New("no earlier step in the journal")
+
+// Journal keeps what every executed step has changed, so the program can step back (see Program.StepBack).
+// It is filled while it is assigned to Program.Journal and cleared by LoadCode and Restore.
+//
+// Inputs consumed by the undone steps are read again from the journal when the program steps forward, whatever its
+// Input is. Undone outputs are removed from SliceOutput, outputs written elsewhere stay written and
+// the program writes them again. Events recorded by Session and Watcher (except its Log) are removed, other
+// instrumentation like Profile or Coverage is not undone, except for the run counted by Coverage when the program
+// completes.
+type Journal struct {
+    // Maximum number of steps kept, the oldest ones are forgotten (0 for no limit)
+    Limit   int
+
+    entries []journalEntry
+    // Inputs of the undone steps, the last one is read first
+    replay  []int64
+}
+
+// State of the program before the step and its changes
+type journalEntry struct {
+    position     int
+    relativeBase int
+    steps        int64
+    budgetUsed   int64
+    completed    bool
+    halt         bool
+    fault        error
+
+    writes       []journalWrite
+    input        int64
+    hasInput     bool
+    output       bool
+}
+
+type journalWrite struct {
+    address  int64
+    oldValue int64
+    oldBig   *big.Int
+}
+
+func NewJournal(limit int) *Journal {
+    return &Journal{Limit: limit}
+}
+
+// Len returns number of steps the program can step back
+func (j *Journal) Len() int {
+    return len(j.entries)
+}
+
+// Replay returns inputs of the undone steps in the order the program reads them again
+func (j *Journal) Replay() []int64 {
+    values := make([]int64, len(j.replay))
+    for k, value := range j.replay {
+        values[len(values) - 1 - k] = value
+    }
+    return values
+}
+
+func (j *Journal) reset() {
+    if j != nil {
+        j.entries = nil
+        j.replay = nil
+    }
+}
+
+func (j *Journal) replaying() bool {
+    return j != nil && len(j.replay) > 0
+}
+
+func (j *Journal) begin(p *Program) {
+    if j.Limit > 0 && len(j.entries) >= j.Limit {
+        // Entries are shifted rarely, the slice is trimmed by a quarter of the limit at once
+        drop := j.Limit / 4 + 1
+        j.entries = append(j.entries[:0], j.entries[drop:]...)
+    }
+
+    j.entries = append(j.entries, journalEntry{
+        position:     p.Position,
+        relativeBase: p.RelativeBase,
+        steps:        p.Steps,
+        budgetUsed:   p.BudgetUsed,
+        completed:    p.Completed,
+        halt:         p.Halt,
+        fault:        p.fault,
+    })
+}
+
+// Drops the entry of the step that was not executed
+func (j *Journal) discard() {
+    j.entries = j.entries[:len(j.entries) - 1]
+}
+
+func (j *Journal) current() *journalEntry {
+    return &j.entries[len(j.entries) - 1]
+}
+
+func (j *Journal) recordWrite(p *Program, address int64, oldValue int64) {
+    j.current().writes = append(j.current().writes, journalWrite{address: address, oldValue: oldValue, oldBig: p.bigMemory[address]})
+}
+
+// Returns input consumed by an undone step, it has to be checked by replaying first
+func (j *Journal) nextReplay() int64 {
+    value := j.replay[len(j.replay) - 1]
+    j.replay = j.replay[:len(j.replay) - 1]
+    return value
+}
+
+func (j *Journal) recordInput(value int64) {
+    j.current().input = value
+    j.current().hasInput = true
+}
+
+func (j *Journal) recordOutput() {
+    j.current().output = true
+}
+
+// StepBack undoes the last executed step
+func (p *Program) StepBack() error {
+    if p.Journal == nil || len(p.Journal.entries) == 0 {
+        return ErrJournalExhausted
+    }
+
+    j := p.Journal
+    entry := j.current()
+    j.entries = j.entries[:len(j.entries) - 1]
+
+    for k := len(entry.writes) - 1; k >= 0; k-- {
+        write := entry.writes[k]
+        p.WriteMemory(write.address, write.oldValue)
+        if write.oldBig != nil {
+            p.bigMemory[write.address] = write.oldBig
+        }
+    }
+
+    if entry.hasInput {
+        j.replay = append(j.replay, entry.input)
+    }
+    if entry.output {
+        p.undoOutput()
+    }
+    if p.Session != nil {
+        p.Session.undo(entry.steps + 1)
+    }
+    if p.Watcher != nil {
+        p.Watcher.undo(entry.steps + 1)
+    }
+    if p.Completed && !entry.completed && p.Coverage != nil {
+        // The step has completed the program, it counts as a run only when it completes it again
+        p.Coverage.Runs--
+    }
+
+    p.Position = entry.position
+    p.RelativeBase = entry.relativeBase
+    p.Steps = entry.steps
+    p.BudgetUsed = entry.budgetUsed
+    p.Completed = entry.completed
+    p.Halt = entry.halt
+    p.fault = entry.fault
+    return nil
+}
+
+// Removes the last output from the output that keeps it
+func (p *Program) undoOutput() {
+    if output, ok := p.Output.(*SliceOutput); ok && len(output.Values) > 0 {
+        output.Values = output.Values[:len(output.Values) - 1]
+    }
+}
+
+// Rewind steps back until the program is in the state before given step was executed, steps are numbered from 1
+// like in TraceRecord, MemoryAccess and SessionEvent, so Steps equals step - 1 afterwards
+func (p *Program) Rewind(step int64) error {
+    if step < 1 || step > p.Steps + 1 {
+        return fmt.Errorf("step %d is not between 1 and the next step %d", step, p.Steps + 1)
+    }
+    target := step - 1
+    if target < p.Steps && (p.Journal == nil || len(p.Journal.entries) == 0 || p.Journal.entries[0].steps > target) {
+        return ErrJournalExhausted
+    }
+
+    for p.Steps > target {
+        if err := p.StepBack(); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// StepBackToWrite steps back until the last step that has written the address is undone, the program is then
+// right before the instruction that has written it. The program stays where it is when there is no such step.
+func (p *Program) StepBackToWrite(address int64) error {
+    if p.Journal == nil {
+        return ErrJournalExhausted
+    }
+
+    for k := len(p.Journal.entries) - 1; k >= 0; k-- {
+        for _, write := range p.Journal.entries[k].writes {
+            if write.address == address {
+                return p.Rewind(p.Journal.entries[k].steps + 1)
+            }
+        }
+    }
+    return fmt.Errorf("address %d was not written in the last %d steps", address, len(p.Journal.entries))
+}
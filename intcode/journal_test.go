@@ -0,0 +1,176 @@
+package intcode
+
+import (
+    "errors"
+    "reflect"
+    "testing"
+)
+
+// Addresses of a and b are 13 and 14, both are within the loaded code
+const journalSource = `
+    IN   [a]
+    ADD  [a], #1, [b]
+    OUT  [b]
+    IN   [a]
+    OUT  [a]
+    HLT
+a: data 0
+b: data 0
+`
+
+func newJournalProgram(t *testing.T, limit int, inputs ...int64) *Program {
+    code, err := Assemble(journalSource)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    program := &Program{
+        Quiet:    true,
+        Input:    NewQueueInput(inputs...),
+        Output:   &SliceOutput{},
+        Journal:  NewJournal(limit),
+        Session:  &Session{},
+        Watcher:  &Watcher{AuditCode: true},
+        Coverage: NewCoverage(),
+    }
+    program.LoadCode(code)
+    return program
+}
+
+func checkOutputs(t *testing.T, p *Program, expected ...int64) {
+    t.Helper()
+    if outputs := p.Output.(*SliceOutput).Values; !reflect.DeepEqual(outputs, expected) && len(outputs) + len(expected) > 0 {
+        t.Errorf("outputs %v, expected %v", outputs, expected)
+    }
+}
+
+func TestStepBack(t *testing.T) {
+    p := newJournalProgram(t, 0, 5, 7)
+    if err := p.Execute(); err != nil {
+        t.Fatal(err)
+    }
+    checkOutputs(t, p, 6, 7)
+
+    if err := p.StepBack(); err != nil {
+        t.Fatal(err)
+    }
+    if p.Completed || p.Steps != 5 || p.Position != 12 {
+        t.Errorf("after stepping back over HLT: completed %v, steps %d, position %d", p.Completed, p.Steps, p.Position)
+    }
+    if err := p.StepBack(); err != nil {
+        t.Fatal(err)
+    }
+    checkOutputs(t, p, 6)
+
+    // The program completes again, it counts as a single run
+    if err := p.Execute(); err != nil {
+        t.Fatal(err)
+    }
+    checkOutputs(t, p, 6, 7)
+    if p.Coverage.Runs != 1 {
+        t.Errorf("coverage counts %d runs, expected 1", p.Coverage.Runs)
+    }
+}
+
+func TestRewindReplaysInput(t *testing.T) {
+    p := newJournalProgram(t, 0, 5, 7)
+    if err := p.Execute(); err != nil {
+        t.Fatal(err)
+    }
+
+    // State before step 4 (the second IN) was executed
+    if err := p.Rewind(4); err != nil {
+        t.Fatal(err)
+    }
+    if p.Steps != 3 || p.Position != 8 {
+        t.Errorf("rewound to step %d, position %d, expected 3 and 8", p.Steps, p.Position)
+    }
+    if value, _ := p.ReadMemory(13); value != 5 {
+        t.Errorf("a = %d, expected 5", value)
+    }
+    checkOutputs(t, p, 6)
+    if replay := p.Journal.Replay(); !reflect.DeepEqual(replay, []int64{7}) {
+        t.Errorf("inputs to replay %v, expected [7]", replay)
+    }
+    if len(p.Session.Events) != 2 || len(p.Watcher.CodeWrites) != 2 {
+        t.Errorf("%d session events and %d code writes kept, expected 2 and 2", len(p.Session.Events), len(p.Watcher.CodeWrites))
+    }
+
+    // The queue is empty, the undone input is read again from the journal
+    if err := p.Execute(); err != nil {
+        t.Fatal(err)
+    }
+    checkOutputs(t, p, 6, 7)
+    if len(p.Session.Events) != 4 || len(p.Watcher.CodeWrites) != 3 {
+        t.Errorf("%d session events and %d code writes, expected 4 and 3", len(p.Session.Events), len(p.Watcher.CodeWrites))
+    }
+
+    for _, step := range []int64{0, p.Steps + 2} {
+        if err := p.Rewind(step); err == nil {
+            t.Errorf("rewind to step %d succeeded", step)
+        }
+    }
+}
+
+func TestStepBackToWrite(t *testing.T) {
+    p := newJournalProgram(t, 0, 5, 7)
+    if err := p.Execute(); err != nil {
+        t.Fatal(err)
+    }
+
+    // b is written only by step 2
+    if err := p.StepBackToWrite(14); err != nil {
+        t.Fatal(err)
+    }
+    if p.Steps != 1 || p.Position != 2 {
+        t.Errorf("stepped back to step %d, position %d, expected 1 and 2", p.Steps, p.Position)
+    }
+    if value, _ := p.ReadMemory(14); value != 0 {
+        t.Errorf("b = %d, expected 0", value)
+    }
+
+    if err := p.StepBackToWrite(100); err == nil {
+        t.Error("stepped back to write of address that was never written")
+    }
+}
+
+func TestJournalLimit(t *testing.T) {
+    p := newJournalProgram(t, 2, 5, 7)
+    if err := p.Execute(); err != nil {
+        t.Fatal(err)
+    }
+
+    for k := 0; k < 2; k++ {
+        if err := p.StepBack(); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if err := p.StepBack(); !errors.Is(err, ErrJournalExhausted) {
+        t.Errorf("expected exhausted journal, got %v", err)
+    }
+    if p.Steps != 4 {
+        t.Errorf("stepped back to step %d, expected 4", p.Steps)
+    }
+}
+
+func TestStepBackAfterBudgetExhausted(t *testing.T) {
+    p := newJournalProgram(t, 0, 5, 7)
+    p.Budget = 3
+
+    var exhausted *BudgetExhaustedError
+    if err := p.Execute(); !errors.As(err, &exhausted) {
+        t.Fatalf("expected exhausted budget, got %v", err)
+    }
+    // The refused instruction was not executed, so it is not in the journal
+    if p.Steps != 3 || p.Journal.Len() != 3 {
+        t.Fatalf("%d steps and %d journal entries, expected 3 and 3", p.Steps, p.Journal.Len())
+    }
+
+    if err := p.StepBack(); err != nil {
+        t.Fatal(err)
+    }
+    if p.Steps != 2 || p.Halt || p.BudgetUsed != 2 {
+        t.Errorf("stepped back to step %d with halt %v and budget used %d, expected 2, false and 2", p.Steps, p.Halt, p.BudgetUsed)
+    }
+    checkOutputs(t, p)
+}
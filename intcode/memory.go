@@ -0,0 +1,154 @@
+package intcode
+
+import "fmt"
+
+// Addresses below this limit are kept in the Memory slice which grows on demand, words on higher addresses
+// are stored sparsely so a single far away access does not allocate all the memory in between.
+const denseMemoryLimit = 1 << 20
+
+// MemoryStats describes how much memory the program has used so far
+type MemoryStats struct {
+    // Highest address the program has read or written
+    HighestAddress int64
+    DenseWords     int
+    SparseWords    int
+}
+
+func (s MemoryStats) String() string {
+    return fmt.Sprintf("highest address %d, %d words allocated (%d dense, %d sparse)",
+        s.HighestAddress, s.DenseWords + s.SparseWords, s.DenseWords, s.SparseWords)
+}
+
+func (p *Program) MemoryStats() MemoryStats {
+    return MemoryStats{
+        HighestAddress: p.highestAddress,
+        DenseWords:     len(p.Memory),
+        SparseWords:    len(p.sparseMemory),
+    }
+}
+
+// ReadMemory returns the value on given address, memory that has never been written contains zeros.
+func (p *Program) ReadMemory(address int64) (int64, error) {
+    if address < 0 {
+        return 0, &MemoryFaultError{Address: p.Position, Target: address}
+    }
+
+    if address < int64(len(p.Memory)) {
+        return p.Memory[address], nil
+    }
+
+    return p.sparseMemory[address], nil
+}
+
+// WriteMemory stores the value on given address, growing the memory when needed.
+func (p *Program) WriteMemory(address int64, value int64) error {
+    if address < 0 {
+        return &MemoryFaultError{Address: p.Position, Target: address, Write: true}
+    }
+
+    if p.bigMemory != nil {
+        delete(p.bigMemory, address)
+    }
+
+    if address >= int64(len(p.Memory)) {
+        if address >= denseMemoryLimit {
+            if p.sparseMemory == nil {
+                p.sparseMemory = map[int64]int64{}
+            }
+            p.sparseMemory[address] = value
+            return nil
+        }
+        p.growMemory(address)
+    }
+
+    p.invalidateInstruction(address)
+    p.Memory[address] = value
+    return nil
+}
+
+// Grows the dense memory so it contains given address, at least doubling its size to keep the growth amortized
+func (p *Program) growMemory(address int64) {
+    size := 2 * len(p.Memory)
+    if size <= int(address) {
+        size = int(address) + 1
+    }
+    if size > denseMemoryLimit {
+        size = denseMemoryLimit
+    }
+
+    memory := make([]int64, size, size)
+    copy(memory, p.Memory)
+    p.Memory = memory
+}
+
+// Reads memory on behalf of the executed instruction, invalid access stops the program
+func (p *Program) read(address int64) int64 {
+    if address > p.highestAddress {
+        p.highestAddress = address
+    }
+
+    value, err := p.ReadMemory(address)
+    if err != nil {
+        p.memoryFault(err)
+    } else {
+        if p.Coverage != nil {
+            p.Coverage.Read[address]++
+        }
+        if p.Watcher != nil {
+            p.Watcher.recordRead(p, address, value)
+        }
+    }
+
+    return value
+}
+
+// All memory writes of the instructions go through this function so they can be observed
+func (p *Program) store(address int64, value int64) {
+    if address > p.highestAddress {
+        p.highestAddress = address
+    }
+
+    var oldValue int64
+    if (p.traceRecord != nil || p.Watcher != nil || p.Journal != nil) && address >= 0 {
+        oldValue, _ = p.ReadMemory(address)
+    }
+
+    if p.Journal != nil && address >= 0 {
+        p.Journal.recordWrite(p, address, oldValue)
+    }
+
+    if p.traceRecord != nil && address >= 0 {
+        p.traceRecord.Writes = append(p.traceRecord.Writes, MemoryWrite{Address: address, OldValue: oldValue, NewValue: value})
+    }
+
+    if err := p.WriteMemory(address, value); err != nil {
+        p.memoryFault(err)
+        return
+    }
+
+    if p.Coverage != nil {
+        p.Coverage.Written[address]++
+    }
+    if p.Watcher != nil {
+        p.Watcher.recordWrite(p, address, oldValue, value)
+    }
+}
+
+func (p *Program) memoryFault(err error) {
+    if p.fault == nil {
+        p.fail(err)
+    }
+}
+
+// Returns memory words of the instruction on given address, they are copied only when they exceed dense memory
+func (p *Program) instructionWords(address int) []int64 {
+    if address >= 0 && address + 4 <= len(p.Memory) {
+        return p.Memory[address:address+4]
+    }
+
+    words := make([]int64, 4)
+    for k := range words {
+        words[k], _ = p.ReadMemory(int64(address + k))
+    }
+    return words
+}
@@ -0,0 +1,127 @@
+package intcode
+
+import (
+    "context"
+    "fmt"
+)
+
+// NativeRuntime supports programs transpiled to Go source (see Transpile). Memory and I/O of the transpiled program
+// are kept in a Program, so it behaves like the interpreter and can fall back to it: instructions without translation
+// are interpreted one by one and once the program writes into its translated instructions, the rest of the run
+// is interpreted.
+type NativeRuntime struct {
+    Program  *Program
+    // Set when the program has written into the words of its translated instructions
+    Modified bool
+
+    translated []bool
+}
+
+// Error that stops the transpiled program, it is raised by the runtime functions and returned by Recover
+type nativeFault struct {
+    err error
+}
+
+// NewNativeRuntime prepares the memory with the code, entries are addresses of the translated instructions.
+func NewNativeRuntime(ctx context.Context, code []int64, entries []int, input Input, output Output) *NativeRuntime {
+    r := &NativeRuntime{
+        Program:    &Program{Quiet: true, Input: input, Output: output, ctx: ctx},
+        translated: make([]bool, len(code)),
+    }
+    r.Program.LoadCode(code)
+
+    for _, address := range entries {
+        if instruction, ok := DecodeInstruction(code, address); ok {
+            for k := address; k < address + instruction.Length; k++ {
+                r.translated[k] = true
+            }
+        }
+    }
+
+    return r
+}
+
+// Recover stops the fault raised by the runtime functions and stores its error, it has to be deferred.
+func (r *NativeRuntime) Recover(err *error) {
+    if recovered := recover(); recovered != nil {
+        fault, ok := recovered.(nativeFault)
+        if !ok {
+            panic(recovered)
+        }
+        *err = fault.err
+    }
+}
+
+// Load reads memory for the instruction on given address
+func (r *NativeRuntime) Load(pc int, address int64) int64 {
+    if address >= 0 && address < int64(len(r.Program.Memory)) {
+        return r.Program.Memory[address]
+    }
+    if address < 0 {
+        panic(nativeFault{&MemoryFaultError{Address: pc, Target: address}})
+    }
+
+    value, _ := r.Program.ReadMemory(address)
+    return value
+}
+
+// Store writes memory for the instruction on given address and reports whether it has modified translated code
+func (r *NativeRuntime) Store(pc int, address int64, value int64) bool {
+    if address < 0 {
+        panic(nativeFault{&MemoryFaultError{Address: pc, Target: address, Write: true}})
+    }
+    r.Program.WriteMemory(address, value)
+
+    if address < int64(len(r.translated)) && r.translated[address] {
+        r.Modified = true
+    }
+    return r.Modified
+}
+
+// In reads input of the instruction on given address
+func (r *NativeRuntime) In(pc int) int64 {
+    value, err := r.Program.input().ReadInput(r.Program.context())
+    if err != nil {
+        panic(nativeFault{inputError(pc, err)})
+    }
+    return value
+}
+
+// Out writes output of the instruction on given address
+func (r *NativeRuntime) Out(pc int, value int64) {
+    if err := r.Program.output().WriteOutput(r.Program.context(), value); err != nil {
+        panic(nativeFault{fmt.Errorf("writing output at address %d: %w", pc, err)})
+    }
+}
+
+// Step interprets single instruction that has no translation, it returns the new position and relative base
+// and whether the program has completed.
+func (r *NativeRuntime) Step(pc int, rb int) (int, int, bool) {
+    p := r.Program
+    p.Position, p.RelativeBase = pc, rb
+
+    target := int64(-1)
+    if instruction, ok := DecodeInstruction(p.Memory, pc); ok && instruction.doesStoreOutputInMemory() {
+        param := instruction.Params[len(instruction.Params) - 1]
+        target = param.Value
+        if param.Mode == RelativeMode {
+            target += int64(rb)
+        }
+    }
+
+    if err := p.Step(); err != nil {
+        panic(nativeFault{err})
+    }
+
+    if target >= 0 && target < int64(len(r.translated)) && r.translated[target] {
+        r.Modified = true
+    }
+    return p.Position, p.RelativeBase, p.Completed
+}
+
+// Interpret runs the rest of the program in the interpreter from given position and relative base
+func (r *NativeRuntime) Interpret(pc int, rb int) error {
+    p := r.Program
+    p.Position, p.RelativeBase = pc, rb
+    return p.ExecuteContext(p.context())
+}
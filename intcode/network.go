@@ -0,0 +1,365 @@
+package intcode
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "strings"
+    "sync"
+)
+
+// Network runs several machines concurrently, each of them on its own goroutine. Machines are addressed by their
+// names and connected by directed links between their ports, every value written to an output port is sent to all
+// the input ports it is connected to (fan-out). An input port can receive from several output ports (fan-in),
+// values are then queued in the order they arrived. Any directed graph (chain, ring, ...) can be built this way.
+//
+// Machine added by Add has single input port "in" and single output port "out", AddWithPorts gives it more of them.
+// Ports are addressed as "machine.port", the machine name alone means its only port of the needed direction.
+type Network struct {
+    machines map[string]*networkMachine
+    // Names of the machines in the order they were added
+    names    []string
+
+    mutex    sync.Mutex
+    // Broadcast whenever a value is queued, a machine stops or the network is stopped
+    changed  *sync.Cond
+    deadlock bool
+    // Machines that were waiting for input when the deadlock was detected
+    blocked  []string
+    stopped  bool
+    // Set while Run executes the machines, no machine can be added then
+    running  bool
+    // Error of the first machine that failed on its own, not by the deadlock or the end of the network
+    failure  error
+}
+
+// Names of the ports of machines added by Add
+const (
+    DefaultInputPort  = "in"
+    DefaultOutputPort = "out"
+)
+
+type networkMachine struct {
+    name       string
+    program    *Program
+    inputs     []*networkPort
+    outputs    []*networkPort
+    // Ports used by the next read and write, the machine goes through its ports in turns
+    nextInput  int
+    nextOutput int
+
+    // All the values the machine has produced
+    produced   []int64
+    waiting    bool
+    done       bool
+}
+
+type networkPort struct {
+    name    string
+    // Input ports that receive the values written to the output port
+    targets []*networkPort
+    // Values waiting to be read from the input port
+    queue   []int64
+    // All the values written to the output port
+    values  []int64
+}
+
+func NewNetwork() *Network {
+    n := &Network{machines: map[string]*networkMachine{}}
+    n.changed = sync.NewCond(&n.mutex)
+    return n
+}
+
+// Add puts the program into the network under given name with the default ports, its Input and Output are replaced
+// by the network.
+func (n *Network) Add(name string, program *Program) error {
+    return n.AddWithPorts(name, program, []string{DefaultInputPort}, []string{DefaultOutputPort})
+}
+
+// AddWithPorts puts the program into the network with the named input and output ports (at least one of each).
+// The program reads its inputs from the input ports in turns: the first read from the first port, the second read
+// from the second one and so on, starting over after the last one. Outputs are written to the output ports the same
+// way, so a program that writes pairs (x, y) can send them to two different machines. Machines can not be added
+// while the network runs.
+func (n *Network) AddWithPorts(name string, program *Program, inputs []string, outputs []string) error {
+    n.mutex.Lock()
+    defer n.mutex.Unlock()
+
+    if n.running {
+        return fmt.Errorf("machine %q can not be added while the network runs", name)
+    }
+    if _, ok := n.machines[name]; ok {
+        return fmt.Errorf("machine %q is already in the network", name)
+    }
+    if len(inputs) == 0 || len(outputs) == 0 {
+        return fmt.Errorf("machine %q needs at least one input and one output port", name)
+    }
+
+    m := &networkMachine{name: name, program: program}
+    var err error
+    if m.inputs, err = newNetworkPorts(name, inputs); err != nil {
+        return err
+    }
+    if m.outputs, err = newNetworkPorts(name, outputs); err != nil {
+        return err
+    }
+
+    program.Input = InputFunc(func(ctx context.Context) (int64, error) {
+        return n.receive(ctx, m)
+    })
+    program.Output = OutputFunc(func(ctx context.Context, value int64) error {
+        n.send(m, value)
+        return nil
+    })
+
+    n.machines[name] = m
+    n.names = append(n.names, name)
+    return nil
+}
+
+func newNetworkPorts(machine string, names []string) ([]*networkPort, error) {
+    ports := make([]*networkPort, len(names))
+    for k, name := range names {
+        if name == "" || strings.Contains(name, ".") {
+            return nil, fmt.Errorf("invalid port name %q of machine %q", name, machine)
+        }
+        for _, port := range ports[:k] {
+            if port.name == name {
+                return nil, fmt.Errorf("machine %q has port %q twice", machine, name)
+            }
+        }
+        ports[k] = &networkPort{name: name}
+    }
+    return ports, nil
+}
+
+// Connect sends the values written to the output port to the input port, like Connect("A.out", "B.in"). Ports can be
+// connected also while the network runs, the values written before are not sent.
+func (n *Network) Connect(from string, to string) error {
+    n.mutex.Lock()
+    defer n.mutex.Unlock()
+
+    source, err := n.port(from, false)
+    if err != nil {
+        return err
+    }
+    target, err := n.port(to, true)
+    if err != nil {
+        return err
+    }
+
+    source.targets = append(source.targets, target)
+    return nil
+}
+
+// Feed queues the values on the input port, it is usually used to provide initial inputs before Run.
+func (n *Network) Feed(to string, values ...int64) error {
+    n.mutex.Lock()
+    defer n.mutex.Unlock()
+
+    port, err := n.port(to, true)
+    if err != nil {
+        return err
+    }
+
+    port.queue = append(port.queue, values...)
+    n.changed.Broadcast()
+    return nil
+}
+
+// Outputs returns all the values written to the output port so far, the machine name alone returns all the values
+// the machine has produced on any of its ports.
+func (n *Network) Outputs(from string) []int64 {
+    n.mutex.Lock()
+    defer n.mutex.Unlock()
+
+    if m, ok := n.machines[from]; ok {
+        return append([]int64(nil), m.produced...)
+    }
+    if port, err := n.port(from, false); err == nil {
+        return append([]int64(nil), port.values...)
+    }
+    return nil
+}
+
+// Run executes all the machines until every one of them completes. The error of the first failed machine is returned,
+// the other machines keep running until they complete or deadlock (machines waiting for the output of the failed
+// one usually do). When no machine has failed, but all the machines that are still running wait for input and there
+// is none queued for them, the network is deadlocked and DeadlockError is returned.
+func (n *Network) Run(ctx context.Context) error {
+    ctx, cancel := context.WithCancel(ctx)
+    defer cancel()
+
+    n.mutex.Lock()
+    if n.running {
+        n.mutex.Unlock()
+        return errors.New("network is already running")
+    }
+    n.running = true
+    n.deadlock, n.blocked, n.stopped, n.failure = false, nil, false, nil
+    for _, m := range n.machines {
+        m.waiting, m.done = false, false
+    }
+    n.mutex.Unlock()
+
+    // Machines waiting for input have to notice the end of the context
+    watcherDone := make(chan struct{})
+    go func() {
+        defer close(watcherDone)
+        <-ctx.Done()
+        n.mutex.Lock()
+        n.stopped = true
+        n.changed.Broadcast()
+        n.mutex.Unlock()
+    }()
+
+    var wg sync.WaitGroup
+    for _, name := range n.names {
+        wg.Add(1)
+        go func(m *networkMachine) {
+            defer wg.Done()
+            n.runMachine(ctx, m)
+        }(n.machines[name])
+    }
+    wg.Wait()
+    cancel()
+    <-watcherDone
+
+    n.mutex.Lock()
+    n.running = false
+    n.mutex.Unlock()
+
+    if n.failure != nil {
+        return n.failure
+    }
+    if n.deadlock {
+        return &DeadlockError{Machines: n.blocked}
+    }
+    return nil
+}
+
+func (n *Network) runMachine(ctx context.Context, m *networkMachine) {
+    var err error
+    defer func() {
+        n.mutex.Lock()
+        // Machines starved by the deadlock fail only after it was detected, the failure that caused it comes first
+        if err != nil && n.failure == nil && !n.deadlock {
+            n.failure = fmt.Errorf("machine %s: %w", m.name, err)
+        }
+        m.done = true
+        n.checkDeadlock()
+        n.changed.Broadcast()
+        n.mutex.Unlock()
+    }()
+
+    err = m.program.ExecuteContext(ctx)
+}
+
+func (n *Network) receive(ctx context.Context, m *networkMachine) (int64, error) {
+    n.mutex.Lock()
+    defer n.mutex.Unlock()
+
+    m.waiting = true
+    defer func() {
+        m.waiting = false
+    }()
+
+    port := m.inputs[m.nextInput]
+    for len(port.queue) == 0 {
+        if n.deadlock {
+            return 0, ErrNoInput
+        }
+        if n.stopped {
+            return 0, ctx.Err()
+        }
+
+        if n.checkDeadlock() {
+            n.changed.Broadcast()
+            continue
+        }
+        n.changed.Wait()
+    }
+
+    value := port.queue[0]
+    port.queue = port.queue[1:]
+    m.nextInput = (m.nextInput + 1) % len(m.inputs)
+    return value, nil
+}
+
+func (n *Network) send(m *networkMachine, value int64) {
+    n.mutex.Lock()
+    defer n.mutex.Unlock()
+
+    port := m.outputs[m.nextOutput]
+    m.nextOutput = (m.nextOutput + 1) % len(m.outputs)
+
+    m.produced = append(m.produced, value)
+    port.values = append(port.values, value)
+    for _, target := range port.targets {
+        target.queue = append(target.queue, value)
+    }
+    n.changed.Broadcast()
+}
+
+// Marks the network as deadlocked when every machine is either done or waits for input with nothing queued on the
+// port it reads from.
+// It has to be called with the mutex locked.
+func (n *Network) checkDeadlock() bool {
+    if n.deadlock {
+        return true
+    }
+
+    running := 0
+    for _, m := range n.machines {
+        if m.done {
+            continue
+        }
+        if !m.waiting || len(m.inputs[m.nextInput].queue) > 0 {
+            return false
+        }
+        running++
+    }
+
+    // When all the machines are done, the network has simply finished
+    if running == 0 {
+        return false
+    }
+
+    n.deadlock = true
+    for _, name := range n.names {
+        if !n.machines[name].done {
+            n.blocked = append(n.blocked, name)
+        }
+    }
+    return true
+}
+
+// Finds the port by its address "machine.port" or by the machine name when it has single port of the direction
+func (n *Network) port(address string, input bool) (*networkPort, error) {
+    direction, ports := "output", func(m *networkMachine) []*networkPort { return m.outputs }
+    if input {
+        direction, ports = "input", func(m *networkMachine) []*networkPort { return m.inputs }
+    }
+
+    if m, ok := n.machines[address]; ok {
+        if len(ports(m)) != 1 {
+            return nil, fmt.Errorf("machine %q has %d %s ports, use %s.<port>", address, len(ports(m)), direction, address)
+        }
+        return ports(m)[0], nil
+    }
+
+    dot := strings.LastIndex(address, ".")
+    if dot < 0 {
+        return nil, fmt.Errorf("unknown machine %q", address)
+    }
+    m, ok := n.machines[address[:dot]]
+    if !ok {
+        return nil, fmt.Errorf("unknown machine %q", address[:dot])
+    }
+    for _, port := range ports(m) {
+        if port.name == address[dot + 1:] {
+            return port, nil
+        }
+    }
+    return nil, fmt.Errorf("machine %q has no %s port %q", m.name, direction, address[dot + 1:])
+}
@@ -0,0 +1,239 @@
+package intcode
+
+import (
+    "context"
+    "errors"
+    "reflect"
+    "sort"
+    "strings"
+    "testing"
+    "time"
+)
+
+// Reads single value, writes it increased by one and halts
+const incrementSource = `
+    IN   [value]
+    ADD  [value], #1, [value]
+    OUT  [value]
+    HLT
+value: data 0
+`
+
+// Increments three values in a loop
+const loopSource = `
+loop: IN   [value]
+      ADD  [value], #1, [value]
+      OUT  [value]
+      ADD  [count], #-1, [count]
+      JT   [count], #loop
+      HLT
+value: data 0
+count: data 3
+`
+
+// Writes every value it reads twice: as it is and multiplied by 10, two rounds
+const splitSource = `
+    IN   [value]
+    OUT  [value]
+    MUL  [value], #10, [value]
+    OUT  [value]
+    IN   [value]
+    OUT  [value]
+    MUL  [value], #10, [value]
+    OUT  [value]
+    HLT
+value: data 0
+`
+
+// Writes the sums of two pairs of values
+const sumSource = `
+    IN   [a]
+    IN   [b]
+    ADD  [a], [b], [a]
+    OUT  [a]
+    IN   [a]
+    IN   [b]
+    ADD  [a], [b], [a]
+    OUT  [a]
+    HLT
+a: data 0
+b: data 0
+`
+
+func newNetworkProgram(t *testing.T, source string) *Program {
+    code, err := Assemble(source)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    program := &Program{Quiet: true}
+    program.LoadCode(code)
+    return program
+}
+
+// Builds the network from the machines (name: source) and links ("from to"), with the values fed before Run
+func newTestNetwork(t *testing.T, machines map[string]string, links []string, feed map[string][]int64) *Network {
+    network := NewNetwork()
+    for _, name := range []string{"a", "b", "c", "d"} {
+        if source, ok := machines[name]; ok {
+            if err := network.Add(name, newNetworkProgram(t, source)); err != nil {
+                t.Fatal(err)
+            }
+        }
+    }
+    for _, link := range links {
+        ends := strings.Fields(link)
+        if err := network.Connect(ends[0], ends[1]); err != nil {
+            t.Fatal(err)
+        }
+    }
+    for name, values := range feed {
+        if err := network.Feed(name, values...); err != nil {
+            t.Fatal(err)
+        }
+    }
+    return network
+}
+
+func TestNetworkRun(t *testing.T) {
+    tests := []struct {
+        name     string
+        machines map[string]string
+        links    []string
+        feed     map[string][]int64
+        outputs  map[string][]int64
+        // Values of several senders arrive in any order, outputs are compared sorted
+        sorted   bool
+    }{
+        {
+            name:     "chain",
+            machines: map[string]string{"a": incrementSource, "b": incrementSource, "c": incrementSource},
+            links:    []string{"a b", "b.out c.in"},
+            feed:     map[string][]int64{"a": {10}},
+            outputs:  map[string][]int64{"a": {11}, "b": {12}, "c": {13}},
+        },
+        {
+            name:     "ring",
+            machines: map[string]string{"a": loopSource, "b": loopSource},
+            links:    []string{"a b", "b a"},
+            feed:     map[string][]int64{"a": {0}},
+            outputs:  map[string][]int64{"a": {1, 3, 5}, "b": {2, 4, 6}},
+        },
+        {
+            name:     "fan-out",
+            machines: map[string]string{"a": incrementSource, "b": incrementSource, "c": incrementSource},
+            links:    []string{"a b", "a c"},
+            feed:     map[string][]int64{"a": {1}},
+            outputs:  map[string][]int64{"a": {2}, "b": {3}, "c": {3}},
+        },
+        {
+            name:     "fan-in",
+            machines: map[string]string{"a": incrementSource, "b": incrementSource, "c": loopSource},
+            links:    []string{"a c", "b c"},
+            feed:     map[string][]int64{"a": {10}, "b": {20}, "c": {30}},
+            outputs:  map[string][]int64{"c": {12, 22, 31}},
+            sorted:   true,
+        },
+    }
+
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            network := newTestNetwork(t, test.machines, test.links, test.feed)
+            if err := network.Run(context.Background()); err != nil {
+                t.Fatal(err)
+            }
+
+            for name, expected := range test.outputs {
+                outputs := network.Outputs(name)
+                if test.sorted {
+                    sort.Slice(outputs, func(i, j int) bool { return outputs[i] < outputs[j] })
+                }
+                if !reflect.DeepEqual(outputs, expected) {
+                    t.Errorf("machine %s: outputs %v, expected %v", name, outputs, expected)
+                }
+            }
+        })
+    }
+}
+
+func TestNetworkNamedPorts(t *testing.T) {
+    network := NewNetwork()
+    if err := network.AddWithPorts("split", newNetworkProgram(t, splitSource), []string{"in"}, []string{"x", "y"}); err != nil {
+        t.Fatal(err)
+    }
+    if err := network.AddWithPorts("sum", newNetworkProgram(t, sumSource), []string{"a", "b"}, []string{"out"}); err != nil {
+        t.Fatal(err)
+    }
+
+    for _, link := range [][2]string{{"split.x", "sum.a"}, {"split.y", "sum.b"}} {
+        if err := network.Connect(link[0], link[1]); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if err := network.Feed("split", 1, 2); err != nil {
+        t.Fatal(err)
+    }
+
+    // Ports have to be named when the machine has several of them
+    if err := network.Connect("split", "sum.a"); err == nil {
+        t.Error("output of machine with two output ports connected by its name")
+    }
+    if err := network.Feed("sum", 1); err == nil {
+        t.Error("machine with two input ports fed by its name")
+    }
+    if err := network.Connect("split.z", "sum.a"); err == nil {
+        t.Error("unknown port connected")
+    }
+
+    if err := network.Run(context.Background()); err != nil {
+        t.Fatal(err)
+    }
+
+    expected := map[string][]int64{"split": {1, 10, 2, 20}, "split.x": {1, 2}, "split.y": {10, 20}, "sum": {11, 22}}
+    for name, values := range expected {
+        if outputs := network.Outputs(name); !reflect.DeepEqual(outputs, values) {
+            t.Errorf("%s: outputs %v, expected %v", name, outputs, values)
+        }
+    }
+}
+
+func TestNetworkDeadlock(t *testing.T) {
+    network := newTestNetwork(t, map[string]string{"a": loopSource, "b": loopSource}, []string{"a b", "b a"}, nil)
+
+    err := network.Run(context.Background())
+    var deadlock *DeadlockError
+    if !errors.As(err, &deadlock) {
+        t.Fatalf("expected deadlock, got %v", err)
+    }
+    if !reflect.DeepEqual(deadlock.Machines, []string{"a", "b"}) {
+        t.Errorf("blocked machines %v, expected [a b]", deadlock.Machines)
+    }
+}
+
+func TestNetworkMachineFailure(t *testing.T) {
+    // Machine b waits for the output of a, which fails on its first instruction
+    network := newTestNetwork(t, map[string]string{"a": "data 42", "b": incrementSource}, []string{"a b"}, nil)
+
+    err := network.Run(context.Background())
+    var deadlock *DeadlockError
+    if errors.As(err, &deadlock) {
+        t.Fatalf("failure reported as %v", err)
+    }
+    var opcode *InvalidOpcodeError
+    if !errors.As(err, &opcode) || !strings.HasPrefix(err.Error(), "machine a: ") {
+        t.Fatalf("expected invalid opcode of machine a, got %v", err)
+    }
+}
+
+func TestNetworkCancel(t *testing.T) {
+    // Machine a loops forever, b waits for its output
+    network := newTestNetwork(t, map[string]string{"a": "loop: JT #1, #loop", "b": incrementSource}, []string{"a b"}, nil)
+
+    ctx, cancel := context.WithTimeout(context.Background(), 50 * time.Millisecond)
+    defer cancel()
+
+    err := network.Run(ctx)
+    if !errors.Is(err, context.DeadlineExceeded) {
+        t.Fatalf("expected deadline exceeded, got %v", err)
+    }
+}
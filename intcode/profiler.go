@@ -0,0 +1,137 @@
+package intcode
+
+import (
+    "encoding/csv"
+    "fmt"
+    "io"
+    "sort"
+    "strconv"
+    "time"
+)
+
+// Profile collects execution counts of a Program, it is filled while it is assigned to Program.Profile
+type Profile struct {
+    Steps     int64
+    Duration  time.Duration
+    OpCodes   map[InstructionOperation]int64
+    Addresses map[int]int64
+    // Taken jumps, see ControlFlowGraph.MergeProfile
+    Jumps     map[Jump]int64
+
+    // Operation most recently executed on each address (code might modify itself)
+    addressOperations map[int]InstructionOperation
+}
+
+func NewProfile() *Profile {
+    return &Profile{
+        OpCodes:           map[InstructionOperation]int64{},
+        Addresses:         map[int]int64{},
+        Jumps:             map[Jump]int64{},
+        addressOperations: map[int]InstructionOperation{},
+    }
+}
+
+func (pr *Profile) record(address int, operation InstructionOperation) {
+    pr.Steps++
+    pr.OpCodes[operation]++
+    pr.Addresses[address]++
+    pr.addressOperations[address] = operation
+}
+
+// Jump is a transfer of control from the jump instruction on one address to another address
+type Jump struct {
+    From int
+    To   int
+}
+
+func (pr *Profile) recordJump(from int, to int) {
+    if pr.Jumps == nil {
+        pr.Jumps = map[Jump]int64{}
+    }
+    pr.Jumps[Jump{From: from, To: to}]++
+}
+
+type profileEntry struct {
+    key       int
+    operation InstructionOperation
+    count     int64
+}
+
+func (pr *Profile) sortedOpCodes() []profileEntry {
+    var entries []profileEntry
+    for operation, count := range pr.OpCodes {
+        entries = append(entries, profileEntry{key: int(operation), operation: operation, count: count})
+    }
+    sortProfileEntries(entries)
+    return entries
+}
+
+func (pr *Profile) sortedAddresses() []profileEntry {
+    var entries []profileEntry
+    for address, count := range pr.Addresses {
+        entries = append(entries, profileEntry{key: address, operation: pr.addressOperations[address], count: count})
+    }
+    sortProfileEntries(entries)
+    return entries
+}
+
+// Most executed entries go first, ties are ordered by the key to keep the report stable
+func sortProfileEntries(entries []profileEntry) {
+    sort.Slice(entries, func(a, b int) bool {
+        if entries[a].count != entries[b].count {
+            return entries[a].count > entries[b].count
+        }
+        return entries[a].key < entries[b].key
+    })
+}
+
+func operationName(operation InstructionOperation) string {
+    if mnemonic, ok := Mnemonics[operation]; ok {
+        return mnemonic
+    }
+    return fmt.Sprintf("?%d", int(operation))
+}
+
+// WriteReport prints the totals, executions per operation and the hottest addresses (all of them when top is 0)
+func (pr *Profile) WriteReport(w io.Writer, top int) {
+    fmt.Fprintf(w, "steps: %d, wall time: %v", pr.Steps, pr.Duration)
+    if pr.Duration > 0 {
+        fmt.Fprintf(w, ", %.0f steps/s", float64(pr.Steps) / pr.Duration.Seconds())
+    }
+    fmt.Fprintln(w)
+
+    fmt.Fprintln(w, "\noperations:")
+    for _, entry := range pr.sortedOpCodes() {
+        fmt.Fprintf(w, "  %-4s %12d %6.2f%%\n", operationName(entry.operation), entry.count, pr.percentage(entry.count))
+    }
+
+    fmt.Fprintln(w, "\nhot spots:")
+    for k, entry := range pr.sortedAddresses() {
+        if top > 0 && k >= top {
+            break
+        }
+        fmt.Fprintf(w, "  %5d %-4s %12d %6.2f%%\n", entry.key, operationName(entry.operation), entry.count, pr.percentage(entry.count))
+    }
+}
+
+// WriteCSV writes all operations and addresses with their execution counts as rows of kind,key,mnemonic,count
+func (pr *Profile) WriteCSV(w io.Writer) error {
+    writer := csv.NewWriter(w)
+    rows := [][]string{{"kind", "key", "mnemonic", "count"}}
+
+    for _, entry := range pr.sortedOpCodes() {
+        rows = append(rows, []string{"opcode", strconv.Itoa(entry.key), operationName(entry.operation), strconv.FormatInt(entry.count, 10)})
+    }
+    for _, entry := range pr.sortedAddresses() {
+        rows = append(rows, []string{"address", strconv.Itoa(entry.key), operationName(entry.operation), strconv.FormatInt(entry.count, 10)})
+    }
+
+    return writer.WriteAll(rows)
+}
+
+func (pr *Profile) percentage(count int64) float64 {
+    if pr.Steps == 0 {
+        return 0
+    }
+    return float64(count) * 100 / float64(pr.Steps)
+}
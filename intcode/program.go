@@ -0,0 +1,512 @@
+package intcode
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "io"
+    "io/ioutil"
+    "math/big"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// Program is a single Intcode machine. It reads its inputs from Input and writes outputs to Output.
+// When they are not set, inputs are read from the values given by PushInput and outputs are logged to Standard Output.
+type Program struct {
+    // Memory grows on demand, see ReadMemory and WriteMemory for access to addresses beyond its length
+    Memory       []int64
+    Position     int
+    RelativeBase int
+    Completed    bool
+    Halt         bool
+
+    Input        Input
+    Output       Output
+
+    // Suppresses informational messages (like end of the program) on Standard Output
+    Quiet        bool
+
+    // Number of instructions executed so far
+    Steps        int64
+    // When set, one JSON record (see TraceRecord) is written here for every executed instruction
+    Trace        io.Writer
+    traceRecord  *TraceRecord
+    // When set, executions of every operation and address are counted into it
+    Profile      *Profile
+    // When set, addresses executed as instructions, read and written by the program are recorded into it
+    Coverage     *Coverage
+    // When set, memory accesses hitting its watchpoints and writes into the loaded code are recorded into it
+    Watcher      *Watcher
+    // When set, changes of every step are recorded into it so the program can step back (see StepBack)
+    Journal      *Journal
+    // When set, every input and output is recorded into it, see Session.Replay
+    Session      *Session
+    // Maximum total cost of the executed instructions, 0 means no limit. The instruction that would exceed it
+    // is not executed and the program halts with BudgetExhaustedError.
+    Budget       int64
+    // Costs of the instructions by their operation, operations missing here cost 1
+    Costs        map[InstructionOperation]int64
+    // Total cost of the instructions executed so far, it is counted only when Budget or Costs is set
+    BudgetUsed   int64
+    // Executes the program translated into Go closures (see compile) unless some instrumentation (Trace, Profile,
+    // Coverage, Watcher or Journal) or budget is set or Arithmetic is not wrapping, which needs the interpreter. Memory
+    // changed directly, not by WriteMemory, is noticed only after LoadCode or Restore.
+    Compiled     bool
+    // How results of Add and Multiply that do not fit into int64 are handled
+    Arithmetic   ArithmeticMode
+
+    sparseMemory   map[int64]int64
+    highestAddress int64
+    // Length of the code loaded by LoadCode, see Watcher.AuditCode
+    codeLength     int
+    // Values that do not fit into int64 in BigArithmetic mode by their address
+    bigMemory      map[int64]*big.Int
+    // Operands of the current step that were loaded from bigMemory
+    bigOperands    [maxParams]*big.Int
+    // Error that stopped the program during the current step
+    fault          error
+    // Values given by PushInput, they are read when Input is not set or the program is driven by Run
+    pushed         QueueInput
+    // Context of the running execution, it ends waiting for input or output
+    ctx            context.Context
+    // Set while the program is driven by Run, produced tells that the current step has written runOutput
+    running        bool
+    produced       bool
+    runOutput      int64
+    runBigOutput   *big.Int
+
+    // Instruction of the current step, its parameters are reused so that stepping does not allocate
+    instruction    Instruction
+    // Decoded instructions by their address, see decode
+    decodeCache    []cachedInstruction
+    // Threaded code of the program in Compiled mode, it is translated on the first execution
+    compiled       *threadedCode
+}
+
+func (p *Program) LoadCodeFromFile(file string) error {
+    intInputs, err := ReadCodeFile(file)
+
+    if err != nil {
+        return err
+    }
+
+    p.LoadCode(intInputs)
+    return nil
+}
+
+// LoadCode copies the code into freshly allocated memory of the program.
+func (p *Program) LoadCode(code []int64) {
+    p.Memory = append([]int64(nil), code...)
+    p.codeLength = len(code)
+    p.decodeCache = nil
+    p.compiled = nil
+    p.sparseMemory = nil
+    p.bigMemory = nil
+    p.highestAddress = 0
+    p.Journal.reset()
+}
+
+// PushInput queues values for the program, it reads them in the same order when its Input is not set or when it is
+// driven by Run. The queue holds only inputs, outputs of the program are never read back.
+func (p *Program) PushInput(values ...int64) {
+    p.pushed.Push(values...)
+}
+
+// Execute runs the program until it completes or halts. When the program is stopped by an invalid instruction,
+// memory access or missing input, the returned error describes it (see InvalidOpcodeError, InvalidModeError,
+// ImmediateWriteError, MemoryFaultError and InputStarvedError) and the program is marked as completed.
+// Exhausted Budget ends with BudgetExhaustedError too, but the program is only halted.
+func (p *Program) Execute() error {
+    return p.ExecuteContext(context.Background())
+}
+
+// ExecuteContext runs the program like Execute, but it is stopped as soon as the context is done. Waiting for input
+// ends with InputStarvedError, otherwise the context error is returned.
+func (p *Program) ExecuteContext(ctx context.Context) error {
+    p.ctx = ctx
+    defer func() {
+        p.ctx = nil
+    }()
+
+    if p.Profile != nil {
+        start := time.Now()
+        defer func(profile *Profile) {
+            profile.Duration += time.Since(start)
+        }(p.Profile)
+    }
+
+    if p.Compiled && !p.needsInterpreter() {
+        return p.executeCompiled(ctx)
+    }
+
+    for !p.Completed && !p.Halt {
+        // Checking the context is not free, long computations are interrupted with a small delay
+        if p.Steps % 1024 == 0 && ctx.Err() != nil {
+            return p.fail(ctx.Err())
+        }
+
+        if err := p.Step(); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// Instrumentation, budget and arithmetic modes other than wrapping are implemented only by the interpreter
+func (p *Program) needsInterpreter() bool {
+    return p.Trace != nil || p.Profile != nil || p.Coverage != nil || p.Watcher != nil || p.Journal != nil ||
+        p.Budget > 0 || p.Costs != nil || p.Arithmetic != WrappingArithmetic
+}
+
+func (p *Program) context() context.Context {
+    if p.ctx == nil {
+        return context.Background()
+    }
+    return p.ctx
+}
+
+// Step executes single instruction at the current position of the program
+func (p *Program) Step() (err error) {
+    if p.Journal != nil {
+        p.Journal.begin(p)
+    }
+    p.fault = nil
+
+    if p.Position < 0 {
+        return p.fail(&MemoryFaultError{Address: p.Position, Target: int64(p.Position)})
+    }
+    if int64(p.Position) > p.highestAddress {
+        p.highestAddress = int64(p.Position)
+    }
+
+    address := p.Position
+    instruction, word, cached := p.decode()
+
+    if p.Budget > 0 || p.Costs != nil {
+        if err := p.charge(instruction.Operation); err != nil {
+            // The instruction is not executed, there is nothing to step back
+            if p.Journal != nil {
+                p.Journal.discard()
+            }
+            return err
+        }
+    }
+    p.Steps++
+
+    if p.Profile != nil {
+        p.Profile.record(p.Position, instruction.Operation)
+    }
+
+    // Opcode that does not fit into int64 was stored as 0 by the instruction that has written it
+    if _, ok := p.bigMemory[int64(address)]; ok {
+        return p.fail(&OverflowError{Address: address})
+    }
+
+    if !cached {
+        if err := p.validateInstruction(instruction, word); err != nil {
+            return p.fail(err)
+        }
+        p.cacheInstruction(instruction, word)
+    }
+
+    if p.Coverage != nil {
+        p.Coverage.recordInstruction(address, instruction.Length)
+    }
+
+    if p.Trace != nil {
+        p.traceRecord = newTraceRecord(p, instruction)
+    }
+
+    p.loadParameterValues(instruction)
+
+    // Parameters might have pointed to invalid memory
+    if p.fault != nil {
+        p.traceRecord = nil
+        return p.fault
+    }
+
+    if p.traceRecord != nil {
+        p.traceRecord.addOperands(instruction)
+        // Trace that can not be written stops the program, unless the step has already failed
+        defer func() {
+            if traceErr := p.writeTraceRecord(); traceErr != nil && err == nil {
+                err = p.fail(traceErr)
+            }
+        }()
+    }
+
+    if p.bigMemory != nil && p.hasBigOperands(instruction) {
+        p.executeBig(instruction)
+        return p.fault
+    }
+
+    switch instruction.Operation {
+    case Add:
+        p.doAdd(instruction)
+    case Multiply:
+        p.doMultiply(instruction)
+    case Read:
+        p.doReadInput(instruction)
+    case Write:
+        p.doWriteOutput(instruction)
+    case JumpIfTrue:
+        p.doJumpIfTrue(instruction)
+    case JumpIfFalse:
+        p.doJumpIfFalse(instruction)
+    case LessThan:
+        p.doComparisonLessThan(instruction)
+    case Equals:
+        p.doComparisonEquals(instruction)
+    case SetRelativeBase:
+        p.doUpdateRelativeBase(instruction)
+    case Terminate:
+        if !p.Quiet {
+            fmt.Println("Program finished")
+        }
+        p.complete()
+    }
+
+    if p.Profile != nil && isJump(instruction.Operation) && p.fault == nil && p.Position != address + instruction.Length {
+        p.Profile.recordJump(address, p.Position)
+    }
+
+    return p.fault
+}
+
+func (p *Program) validateInstruction(i *Instruction, value int64) error {
+    if _, ok := InstructionLength[i.Operation]; !ok || value < 0 {
+        return &InvalidOpcodeError{Address: p.Position, Value: value}
+    }
+
+    for j, param := range i.Params {
+        if param.Mode > RelativeMode {
+            return &InvalidModeError{Address: p.Position, Value: value, Parameter: j, Mode: param.Mode}
+        }
+        if param.Mode == ImmediateMode && i.doesStoreOutputInMemory() && j == i.getValuesCount() {
+            return &ImmediateWriteError{Address: p.Position, Value: value}
+        }
+    }
+
+    return nil
+}
+
+// Stops the program because of the error
+func (p *Program) fail(err error) error {
+    p.fault = err
+    p.complete()
+    return err
+}
+
+// Marks the program as completed, completing the completed program does nothing
+func (p *Program) complete() {
+    if p.Coverage != nil && !p.Completed {
+        p.Coverage.Runs++
+    }
+    p.Completed = true
+}
+
+// Parameters can be handled "by value" or "by reference" and this function supplies the end value in each case
+func (p *Program) loadParameterValues(i *Instruction) {
+    if p.bigMemory != nil {
+        p.bigOperands = [maxParams]*big.Int{}
+
+        // Parameters that do not fit into int64 can be used only as immediate operands, not as addresses
+        for j := range i.Params {
+            value, ok := p.bigMemory[int64(p.Position + j + 1)]
+            if !ok {
+                continue
+            }
+            if j >= i.getValuesCount() || i.Params[j].Mode != ImmediateMode {
+                p.fail(&OverflowError{Address: p.Position, Operation: i.Operation})
+                return
+            }
+            p.bigOperands[j] = value
+        }
+    }
+
+    for j := 0; j < i.getValuesCount(); j++ {
+        address := i.Params[j].Value
+        switch i.Params[j].Mode {
+        case PositionMode:
+        case RelativeMode:
+            address += int64(p.RelativeBase)
+        default:
+            continue
+        }
+
+        i.Params[j].Value = p.read(address)
+        if p.bigMemory != nil {
+            p.bigOperands[j] = p.bigMemory[address]
+        }
+    }
+
+    if i.doesStoreOutputInMemory() {
+        if i.Params[i.getValuesCount()].Mode == RelativeMode {
+            i.Params[i.getValuesCount()].Value = int64(p.RelativeBase) + i.Params[i.getValuesCount()].Value
+        }
+    }
+}
+
+func (p *Program) doAdd(i *Instruction) {
+    if p.Arithmetic != WrappingArithmetic {
+        p.calculate(i)
+    } else {
+        p.store(i.Params[2].Value, i.Params[0].Value + i.Params[1].Value)
+    }
+    p.Position += i.Length
+}
+
+func (p *Program) doMultiply(i *Instruction) {
+    if p.Arithmetic != WrappingArithmetic {
+        p.calculate(i)
+    } else {
+        p.store(i.Params[2].Value, i.Params[0].Value * i.Params[1].Value)
+    }
+    p.Position += i.Length
+}
+
+// Inputs are read from Input of the Program, when the input is not available the program fails.
+func (p *Program) doReadInput(i *Instruction) {
+    var input int64
+    var err error
+    if p.Journal.replaying() {
+        input = p.Journal.nextReplay()
+    } else {
+        input, err = p.input().ReadInput(p.context())
+    }
+
+    if err != nil {
+        p.fail(inputError(p.Position, err))
+        return
+    }
+
+    if p.Journal != nil {
+        p.Journal.recordInput(input)
+    }
+    if p.Session != nil {
+        p.Session.recordInput(p.Steps, input)
+    }
+
+    if p.traceRecord != nil {
+        traced := input
+        p.traceRecord.Input = &traced
+    }
+
+    p.store(i.Params[0].Value, input)
+    p.Position += i.Length
+}
+
+// Program outputs are written to Output of the Program
+func (p *Program) doWriteOutput(i *Instruction) {
+    if p.traceRecord != nil {
+        traced := i.Params[0].Value
+        p.traceRecord.Output = &traced
+    }
+
+    if err := p.output().WriteOutput(p.context(), i.Params[0].Value); err != nil {
+        p.fail(fmt.Errorf("writing output at address %d: %w", p.Position, err))
+        return
+    }
+    if p.Journal != nil {
+        p.Journal.recordOutput()
+    }
+    if p.Session != nil {
+        p.Session.recordOutput(p.Steps, i.Params[0].Value)
+    }
+    p.Position += i.Length
+}
+
+// Describes failure of the input read by the instruction on the address
+func inputError(address int, err error) error {
+    if errors.Is(err, ErrNoInput) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+        return &InputStarvedError{Address: address, Err: err}
+    }
+    return fmt.Errorf("reading input at address %d: %w", address, err)
+}
+
+func (p *Program) input() Input {
+    if p.running {
+        return runIO{p}
+    }
+    if p.Input != nil {
+        return p.Input
+    }
+    return &p.pushed
+}
+
+func (p *Program) output() Output {
+    if p.running {
+        return runIO{p}
+    }
+    if p.Output != nil {
+        return p.Output
+    }
+    return programOutput{}
+}
+
+func (p *Program) doJumpIfTrue(i *Instruction) {
+    if i.Params[0].Value != 0 {
+        p.Position = int(i.Params[1].Value)
+    } else {
+        p.Position += i.Length
+    }
+}
+
+func (p *Program) doJumpIfFalse(i *Instruction) {
+    if i.Params[0].Value == 0 {
+        p.Position = int(i.Params[1].Value)
+    } else {
+        p.Position += i.Length
+    }
+}
+
+func (p *Program) doComparisonLessThan(i *Instruction) {
+    if i.Params[0].Value < i.Params[1].Value {
+        p.store(i.Params[2].Value, 1)
+    } else {
+        p.store(i.Params[2].Value, 0)
+    }
+    p.Position += i.Length
+}
+
+func (p *Program) doComparisonEquals(i *Instruction) {
+    if i.Params[0].Value == i.Params[1].Value {
+        p.store(i.Params[2].Value, 1)
+    } else {
+        p.store(i.Params[2].Value, 0)
+    }
+    p.Position += i.Length
+}
+
+func (p *Program) doUpdateRelativeBase(i *Instruction) {
+    p.RelativeBase += int(i.Params[0].Value)
+    p.Position += i.Length
+}
+
+// ReadCodeFile loads comma separated Intcode from the file.
+func ReadCodeFile(file string) ([]int64, error) {
+    bytes, err := ioutil.ReadFile(file)
+
+    if err != nil {
+        return nil, err
+    }
+
+    return ParseCode(string(bytes))
+}
+
+// ParseCode converts comma separated Intcode (as stored in the puzzle input files) into a slice of integers.
+func ParseCode(code string) ([]int64, error) {
+    strArr := strings.Split(strings.TrimSpace(code), ",")
+    iArr := make([]int64, 0, len(strArr))
+    for index, str := range strArr {
+        i, err := strconv.ParseInt(strings.TrimSpace(str), 10, 64)
+        if err != nil {
+            return nil, &ParseError{Index: index, Text: str, Err: err}
+        }
+        iArr = append(iArr, i)
+    }
+    return iArr, nil
+}
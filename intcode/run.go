@@ -0,0 +1,133 @@
+package intcode
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+)
+
+// RunStatus tells why Run has returned
+type RunStatus int
+
+const (
+    // The program waits for input, it continues once the input is given by PushInput
+    NeedsInput RunStatus = iota
+    // The program has produced output, it is in RunResult.Output
+    ProducedOutput
+    // The program has reached the end
+    Halted
+    // The program has stopped with error, it is in RunResult.Err
+    Faulted
+)
+
+// RunResult is the event that stopped Run. Output that does not fit into int64 (see BigArithmetic) is in BigOutput
+// and Output is 0.
+type RunResult struct {
+    Status    RunStatus
+    Output    int64
+    BigOutput *big.Int
+    Err       error
+}
+
+func (r RunResult) String() string {
+    switch r.Status {
+    case NeedsInput:
+        return "needs input"
+    case ProducedOutput:
+        if r.BigOutput != nil {
+            return fmt.Sprintf("produced output %v", r.BigOutput)
+        }
+        return fmt.Sprintf("produced output %d", r.Output)
+    case Halted:
+        return "halted"
+    default:
+        return fmt.Sprintf("faulted: %v", r.Err)
+    }
+}
+
+// Run executes the program until it needs input, produces output or stops, and tells which of these happened.
+// Calling Run again resumes the program from where it has stopped, so the caller drives the program synchronously:
+//
+//     for {
+//         switch result := p.Run(); result.Status {
+//         case NeedsInput:
+//             p.PushInput(next())
+//         case ProducedOutput:
+//             use(result.Output)
+//         case Halted:
+//             return nil
+//         case Faulted:
+//             return result.Err
+//         }
+//     }
+//
+// The program reads only the values given by PushInput and its outputs are only returned, Input and Output are not
+// used. Run always interprets the program, even when Compiled is set.
+func (p *Program) Run() RunResult {
+    return p.RunContext(context.Background())
+}
+
+// RunContext runs the program like Run, but it is stopped with Faulted status as soon as the context is done.
+// Faults that do not complete the program (exhausted Budget or the context) can be resumed by next Run.
+func (p *Program) RunContext(ctx context.Context) RunResult {
+    if p.Completed {
+        if p.fault != nil {
+            return RunResult{Status: Faulted, Err: p.fault}
+        }
+        return RunResult{Status: Halted}
+    }
+
+    p.ctx = ctx
+    p.running = true
+    defer func() {
+        p.ctx = nil
+        p.running = false
+    }()
+
+    for {
+        if p.Steps % 1024 == 0 && ctx.Err() != nil {
+            return RunResult{Status: Faulted, Err: ctx.Err()}
+        }
+
+        // Read instruction is not executed until there is input for it
+        if word, err := p.ReadMemory(int64(p.Position)); err == nil && InstructionOperation(word % 100) == Read &&
+            p.pushed.Len() == 0 && !p.Journal.replaying() {
+            return RunResult{Status: NeedsInput}
+        }
+
+        p.produced = false
+        if err := p.Step(); err != nil {
+            return RunResult{Status: Faulted, Err: err}
+        }
+
+        if p.produced {
+            return RunResult{Status: ProducedOutput, Output: p.runOutput, BigOutput: p.runBigOutput}
+        }
+        if p.Completed {
+            return RunResult{Status: Halted}
+        }
+    }
+}
+
+// Input and output of the program driven by Run
+type runIO struct {
+    p *Program
+}
+
+func (r runIO) ReadInput(ctx context.Context) (int64, error) {
+    return r.p.pushed.ReadInput(ctx)
+}
+
+func (r runIO) WriteOutput(ctx context.Context, value int64) error {
+    r.p.produced = true
+    r.p.runOutput = value
+    r.p.runBigOutput = nil
+    return nil
+}
+
+func (r runIO) WriteBigOutput(ctx context.Context, value *big.Int) error {
+    r.p.produced = true
+    r.p.runOutput = 0
+    r.p.runBigOutput = new(big.Int).Set(value)
+    return nil
+}
@@ -0,0 +1,192 @@
+package intcode
+
+import (
+    "bufio"
+    "encoding/json"
+    "fmt"
+    "io"
+    "math/big"
+    "os"
+)
+
+// SessionEvent is single input or output of the program, Step is the number of the step that has read or written it
+// (see Program.Steps). Outputs that do not fit into int64 (see BigArithmetic) are in BigOutput instead of Output.
+type SessionEvent struct {
+    Step      int64    `json:"step"`
+    Input     *int64   `json:"input,omitempty"`
+    Output    *int64   `json:"output,omitempty"`
+    BigOutput *big.Int `json:"bigOutput,omitempty"`
+}
+
+// Returns the output value of the event including the one that does not fit into int64
+func (e SessionEvent) outputValue() *big.Int {
+    if e.BigOutput != nil {
+        return e.BigOutput
+    }
+    return big.NewInt(*e.Output)
+}
+
+// Session records all the inputs and outputs of a Program in the order they happened, it is filled while it is
+// assigned to Program.Session.
+type Session struct {
+    Events []SessionEvent
+}
+
+func (s *Session) recordInput(step int64, value int64) {
+    s.Events = append(s.Events, SessionEvent{Step: step, Input: &value})
+}
+
+func (s *Session) recordOutput(step int64, value int64) {
+    s.Events = append(s.Events, SessionEvent{Step: step, Output: &value})
+}
+
+func (s *Session) recordBigOutput(step int64, value *big.Int) {
+    s.Events = append(s.Events, SessionEvent{Step: step, BigOutput: new(big.Int).Set(value)})
+}
+
+// Removes the events of the step and the later ones, they were undone by the Journal
+func (s *Session) undo(step int64) {
+    for len(s.Events) > 0 && s.Events[len(s.Events) - 1].Step >= step {
+        s.Events = s.Events[:len(s.Events) - 1]
+    }
+}
+
+// Inputs returns the recorded input values in the order they were read
+func (s *Session) Inputs() []int64 {
+    var values []int64
+    for _, event := range s.Events {
+        if event.Input != nil {
+            values = append(values, *event.Input)
+        }
+    }
+    return values
+}
+
+// Outputs returns the recorded output values in the order they were written, values that do not fit into int64
+// are left out
+func (s *Session) Outputs() []int64 {
+    var values []int64
+    for _, event := range s.Events {
+        if event.Output != nil {
+            values = append(values, *event.Output)
+        }
+    }
+    return values
+}
+
+// Write writes the session as JSON Lines, one event per line
+func (s *Session) Write(w io.Writer) error {
+    encoder := json.NewEncoder(w)
+    for _, event := range s.Events {
+        if err := encoder.Encode(event); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// ReadSession reads the session written by Session.Write
+func ReadSession(r io.Reader) (*Session, error) {
+    session := &Session{}
+    scanner := bufio.NewScanner(r)
+    for line := 1; scanner.Scan(); line++ {
+        var event SessionEvent
+        if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+            return nil, fmt.Errorf("line %d: %v", line, err)
+        }
+        if (event.Input != nil) == (event.Output != nil || event.BigOutput != nil) || (event.Output != nil && event.BigOutput != nil) {
+            return nil, fmt.Errorf("line %d: event has to be either input or output", line)
+        }
+        session.Events = append(session.Events, event)
+    }
+    return session, scanner.Err()
+}
+
+func (s *Session) SaveToFile(file string) error {
+    f, err := os.Create(file)
+    if err != nil {
+        return err
+    }
+
+    if err := s.Write(f); err != nil {
+        f.Close()
+        return err
+    }
+    return f.Close()
+}
+
+func LoadSessionFromFile(file string) (*Session, error) {
+    f, err := os.Open(file)
+    if err != nil {
+        return nil, err
+    }
+    defer f.Close()
+
+    return ReadSession(f)
+}
+
+// Divergence describes the first difference between a replayed program and the recorded session. Step is the step
+// of the replayed program and RecordedStep the step of the recorded event that was expected (0 when the session has
+// no more events of that kind).
+type Divergence struct {
+    Step         int64
+    RecordedStep int64
+    Reason       string
+}
+
+func (d *Divergence) String() string {
+    if d.RecordedStep == 0 {
+        return fmt.Sprintf("step %d: %s", d.Step, d.Reason)
+    }
+    return fmt.Sprintf("step %d (recorded step %d): %s", d.Step, d.RecordedStep, d.Reason)
+}
+
+// Replay runs the program with the recorded inputs and compares its outputs with the recorded ones. The inputs
+// are given in the recorded order whenever the program asks for them, so the replay is deterministic even when
+// the recorded program got them from interactive sources. The first difference is returned as Divergence, nil
+// means the program has produced the same outputs and halted. Error is returned when the program faults before
+// diverging, use Program.Budget to stop a replayed program that loops forever.
+func (s *Session) Replay(p *Program) (*Divergence, error) {
+    var inputs, outputs []SessionEvent
+    for _, event := range s.Events {
+        if event.Input != nil {
+            inputs = append(inputs, event)
+        } else {
+            outputs = append(outputs, event)
+        }
+    }
+
+    read, written := 0, 0
+    for {
+        switch result := p.Run(); result.Status {
+        case NeedsInput:
+            if read == len(inputs) {
+                return &Divergence{Step: p.Steps + 1, Reason: fmt.Sprintf("program reads input %d, only %d were recorded", read + 1, len(inputs))}, nil
+            }
+            p.PushInput(*inputs[read].Input)
+            read++
+        case ProducedOutput:
+            value := result.BigOutput
+            if value == nil {
+                value = big.NewInt(result.Output)
+            }
+            if written == len(outputs) {
+                return &Divergence{Step: p.Steps, Reason: fmt.Sprintf("output %d = %v was not recorded", written + 1, value)}, nil
+            }
+            expected := outputs[written]
+            if expected.outputValue().Cmp(value) != 0 {
+                return &Divergence{Step: p.Steps, RecordedStep: expected.Step,
+                    Reason: fmt.Sprintf("output %d is %v, recorded %v", written + 1, value, expected.outputValue())}, nil
+            }
+            written++
+        case Halted:
+            if written < len(outputs) {
+                return &Divergence{Step: p.Steps, RecordedStep: outputs[written].Step,
+                    Reason: fmt.Sprintf("program halted after %d outputs, %d were recorded", written, len(outputs))}, nil
+            }
+            return nil, nil
+        case Faulted:
+            return nil, result.Err
+        }
+    }
+}
@@ -0,0 +1,132 @@
+package intcode
+
+import (
+    "encoding/json"
+    "io/ioutil"
+    "math/big"
+)
+
+// Snapshot is a copy of the complete state of a Program. Queued input is captured from the values given by PushInput,
+// QueueInput and ASCIIInput (only the characters already read from its reader) and from the steps undone by Journal.
+// Other inputs can not be captured.
+type Snapshot struct {
+    Memory       []int64            `json:"memory"`
+    SparseMemory map[int64]int64    `json:"sparseMemory,omitempty"`
+    // Values that do not fit into int64, see BigArithmetic
+    BigMemory    map[int64]*big.Int `json:"bigMemory,omitempty"`
+    Position     int                `json:"position"`
+    RelativeBase int                `json:"relativeBase"`
+    Completed    bool               `json:"completed"`
+    Halt         bool               `json:"halt"`
+    // Inputs of the steps undone by Journal followed by values queued for the program (see queue)
+    Input        []int64            `json:"input,omitempty"`
+    Steps        int64              `json:"steps"`
+    BudgetUsed   int64              `json:"budgetUsed,omitempty"`
+}
+
+func (p *Program) Snapshot() *Snapshot {
+    return &Snapshot{
+        Memory:       append([]int64(nil), p.Memory...),
+        SparseMemory: copySparseMemory(p.sparseMemory),
+        BigMemory:    copyBigMemory(p.bigMemory),
+        Position:     p.Position,
+        RelativeBase: p.RelativeBase,
+        Completed:    p.Completed,
+        Halt:         p.Halt,
+        Input:        p.pendingInput(),
+        Steps:        p.Steps,
+        BudgetUsed:   p.BudgetUsed,
+    }
+}
+
+// Restore puts the program back into the state of the snapshot, the snapshot itself stays untouched
+// so it can be restored repeatedly. Pending input replaces the values queued for the program by PushInput, QueueInput
+// or ASCIIInput, it is lost for other inputs.
+func (p *Program) Restore(s *Snapshot) {
+    p.Memory = append(p.Memory[:0], s.Memory...)
+    p.decodeCache = nil
+    p.compiled = nil
+    p.sparseMemory = copySparseMemory(s.SparseMemory)
+    p.bigMemory = copyBigMemory(s.BigMemory)
+    p.Position = s.Position
+    p.RelativeBase = s.RelativeBase
+    p.Completed = s.Completed
+    p.Halt = s.Halt
+    p.restorePendingInput(s.Input)
+    p.Steps = s.Steps
+    p.BudgetUsed = s.BudgetUsed
+    p.fault = nil
+    p.Journal.reset()
+}
+
+// Inputs that queue their values, the queue is part of Snapshot
+type queuedInput interface {
+    Len() int
+    Pending() []int64
+    setPending(values []int64)
+}
+
+// Returns the queue the program reads its inputs from: values given by PushInput when Input is not set, otherwise
+// Input that queues its values (nil for other inputs)
+func (p *Program) queue() queuedInput {
+    if p.Input == nil {
+        return &p.pushed
+    }
+    if queue, ok := p.Input.(queuedInput); ok {
+        return queue
+    }
+    return nil
+}
+
+// Returns values the program is going to read: inputs of the undone steps followed by the queued values
+func (p *Program) pendingInput() []int64 {
+    var values []int64
+    if p.Journal.replaying() {
+        values = p.Journal.Replay()
+    }
+    if queue := p.queue(); queue != nil {
+        values = append(values, queue.Pending()...)
+    }
+    return values
+}
+
+func (p *Program) restorePendingInput(values []int64) {
+    if queue := p.queue(); queue != nil {
+        queue.setPending(values)
+    }
+}
+
+func copySparseMemory(memory map[int64]int64) map[int64]int64 {
+    if len(memory) == 0 {
+        return nil
+    }
+
+    result := make(map[int64]int64, len(memory))
+    for address, value := range memory {
+        result[address] = value
+    }
+    return result
+}
+
+func (s *Snapshot) SaveToFile(file string) error {
+    bytes, err := json.Marshal(s)
+    if err != nil {
+        return err
+    }
+
+    return ioutil.WriteFile(file, bytes, 0644)
+}
+
+func LoadSnapshotFromFile(file string) (*Snapshot, error) {
+    bytes, err := ioutil.ReadFile(file)
+    if err != nil {
+        return nil, err
+    }
+
+    var s Snapshot
+    if err := json.Unmarshal(bytes, &s); err != nil {
+        return nil, err
+    }
+
+    return &s, nil
+}
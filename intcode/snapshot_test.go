@@ -0,0 +1,76 @@
+package intcode
+
+import (
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "reflect"
+    "testing"
+)
+
+// Reads a value into the sparse memory, writes it increased by one and then writes the next input
+const snapshotSource = `
+    ARB  #5000000
+    IN   rb+0
+    ADD  rb+0, #1, rb+1
+    OUT  rb+1
+    IN   [a]
+    OUT  [a]
+    HLT
+a:  data 0
+`
+
+func TestSnapshotFile(t *testing.T) {
+    code, err := Assemble(snapshotSource)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    p := &Program{Quiet: true}
+    p.LoadCode(code)
+    p.PushInput(5, 7)
+    for k := 0; k < 2; k++ {
+        if err := p.Step(); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    dir, err := ioutil.TempDir("", "snapshot")
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer os.RemoveAll(dir)
+
+    file := filepath.Join(dir, "snapshot.json")
+    if err := p.Snapshot().SaveToFile(file); err != nil {
+        t.Fatal(err)
+    }
+    snapshot, err := LoadSnapshotFromFile(file)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if !reflect.DeepEqual(snapshot.SparseMemory, map[int64]int64{5000000: 5}) {
+        t.Errorf("sparse memory %v, expected map[5000000:5]", snapshot.SparseMemory)
+    }
+    if !reflect.DeepEqual(snapshot.Input, []int64{7}) {
+        t.Errorf("pending input %v, expected [7]", snapshot.Input)
+    }
+    if snapshot.Position != 4 || snapshot.RelativeBase != 5000000 || snapshot.Steps != 2 {
+        t.Errorf("position %d, relative base %d, steps %d, expected 4, 5000000 and 2", snapshot.Position, snapshot.RelativeBase, snapshot.Steps)
+    }
+
+    // The restored program continues where the saved one stopped
+    restored := &Program{Quiet: true, Output: &SliceOutput{}}
+    restored.Restore(snapshot)
+    if value, _ := restored.ReadMemory(5000000); value != 5 {
+        t.Errorf("sparse value %d, expected 5", value)
+    }
+    if !reflect.DeepEqual(restored.Memory, p.Memory) {
+        t.Errorf("memory %v, expected %v", restored.Memory, p.Memory)
+    }
+    if err := restored.Execute(); err != nil {
+        t.Fatal(err)
+    }
+    checkOutputs(t, restored, 6, 7)
+}
@@ -0,0 +1,69 @@
+package intcode
+
+import (
+    "encoding/json"
+    "fmt"
+    "math/big"
+)
+
+// TraceRecord describes single executed instruction, it is written as one line of JSON into Program.Trace
+type TraceRecord struct {
+    Step         int64         `json:"step"`
+    Address      int           `json:"address"`
+    OpCode       int           `json:"opcode"`
+    Modes        []int         `json:"modes"`
+    // Values of the parameters after resolving their modes (address for the parameter the result is written to)
+    Operands     []int64       `json:"operands"`
+    Writes       []MemoryWrite `json:"writes,omitempty"`
+    // Relative base in effect when the instruction was executed
+    RelativeBase int           `json:"relativeBase"`
+    Input        *int64        `json:"input,omitempty"`
+    Output       *int64        `json:"output,omitempty"`
+    // Output that does not fit into int64 (see BigArithmetic)
+    BigOutput    *big.Int      `json:"bigOutput,omitempty"`
+}
+
+type MemoryWrite struct {
+    Address  int64 `json:"address"`
+    OldValue int64 `json:"old"`
+    NewValue int64 `json:"new"`
+}
+
+func newTraceRecord(p *Program, i *Instruction) *TraceRecord {
+    record := &TraceRecord{
+        Step:         p.Steps,
+        Address:      p.Position,
+        OpCode:       int(i.Operation),
+        Modes:        make([]int, len(i.Params)),
+        RelativeBase: p.RelativeBase,
+    }
+
+    for j, param := range i.Params {
+        record.Modes[j] = param.Mode
+    }
+
+    return record
+}
+
+func (r *TraceRecord) addOperands(i *Instruction) {
+    r.Operands = make([]int64, len(i.Params))
+    for j, param := range i.Params {
+        r.Operands[j] = param.Value
+    }
+}
+
+// Writes the record of the current step into the trace
+func (p *Program) writeTraceRecord() error {
+    record := p.traceRecord
+    p.traceRecord = nil
+
+    data, err := json.Marshal(record)
+    if err != nil {
+        return fmt.Errorf("encoding trace at address %d: %w", record.Address, err)
+    }
+
+    if _, err := p.Trace.Write(append(data, '\n')); err != nil {
+        return fmt.Errorf("writing trace at address %d: %w", record.Address, err)
+    }
+    return nil
+}
@@ -0,0 +1,246 @@
+package intcode
+
+import (
+    "bytes"
+    "fmt"
+    "go/format"
+    "io"
+    "sort"
+    "strings"
+)
+
+// TranspileOptions configure the Go source generated by Transpile
+type TranspileOptions struct {
+    // Package of the generated file, package main gets also main function running the program on Standard I/O
+    Package string
+    // Name of the program file mentioned in the header of the generated file
+    Source  string
+}
+
+// CodeWrite is an instruction that writes into the words of a translated instruction, found when transpiling
+type CodeWrite struct {
+    Address int
+    Target  int64
+}
+
+// Transpile generates Go source implementing the program as a switch over its program counter. The generated
+// function Run(ctx, input, output) has the same I/O interface as Program. Only instructions reachable from the first
+// address (following immediate jump targets) are translated, other addresses are interpreted by NativeRuntime.
+// Writes into the translated instructions known in advance are returned, all of them are also guarded at run time
+// and the program continues in the interpreter once it modifies itself. Targets of relative mode writes depend
+// on the relative base, so such writes into the code are never returned and are caught only at run time.
+func Transpile(code []int64, w io.Writer, options TranspileOptions) ([]CodeWrite, error) {
+    entries := reachableInstructions(code)
+    translated := map[int64]bool{}
+    for _, address := range entries {
+        instruction, _ := DecodeInstruction(code, address)
+        for k := address; k < address + instruction.Length; k++ {
+            translated[int64(k)] = true
+        }
+    }
+
+    var codeWrites []CodeWrite
+    var src bytes.Buffer
+    fmt.Fprintf(&src, "// Code generated by intcode transpiler from %s. DO NOT EDIT.\n\n", options.Source)
+    fmt.Fprintf(&src, "package %s\n\n", options.Package)
+    if options.Package == "main" {
+        fmt.Fprint(&src, "import (\n\"context\"\n\"fmt\"\n\"os\"\n\"strconv\"\n\n\"github.com/tomasbobek/AdventOfCode19/intcode\"\n)\n\n")
+    } else {
+        fmt.Fprint(&src, "import (\n\"context\"\n\n\"github.com/tomasbobek/AdventOfCode19/intcode\"\n)\n\n")
+    }
+
+    fmt.Fprintf(&src, "var code = []int64{%s}\n\n", joinValues(code))
+    entryValues := make([]int64, len(entries))
+    for k, address := range entries {
+        entryValues[k] = int64(address)
+    }
+    fmt.Fprintf(&src, "// Addresses of the instructions translated in Run\nvar entries = []int{%s}\n\n", joinValues(entryValues))
+
+    fmt.Fprint(&src, `// Run executes the program, it reads inputs from input and writes outputs to output
+func Run(ctx context.Context, input intcode.Input, output intcode.Output) (err error) {
+r := intcode.NewNativeRuntime(ctx, code, entries, input, output)
+defer r.Recover(&err)
+
+pc, rb := 0, 0
+for steps := 0; ; steps++ {
+if steps % 1024 == 0 && ctx.Err() != nil {
+return ctx.Err()
+}
+
+switch pc {
+`)
+
+    for _, address := range entries {
+        instruction, _ := DecodeInstruction(code, address)
+        line := disassembleInstruction(code, address, instruction)
+        fmt.Fprintf(&src, "case %d: // %s\n", address, line.Source())
+
+        writeCase(&src, address, &instruction)
+
+        if instruction.doesStoreOutputInMemory() {
+            target := instruction.Params[len(instruction.Params) - 1]
+            if target.Mode == PositionMode && translated[target.Value] {
+                codeWrites = append(codeWrites, CodeWrite{Address: address, Target: target.Value})
+            }
+        }
+    }
+
+    fmt.Fprint(&src, `default:
+var done bool
+if pc, rb, done = r.Step(pc, rb); done {
+return nil
+}
+if r.Modified {
+return r.Interpret(pc, rb)
+}
+}
+}
+}
+`)
+
+    if options.Package == "main" {
+        fmt.Fprint(&src, `
+// Input values are taken from the arguments, when there are none they are read from Standard Input
+func main() {
+var input intcode.Input = intcode.NewReaderInput(os.Stdin)
+if len(os.Args) > 1 {
+queue := intcode.NewQueueInput()
+for _, arg := range os.Args[1:] {
+value, err := strconv.ParseInt(arg, 10, 64)
+if err != nil {
+fmt.Println(err)
+os.Exit(1)
+}
+queue.Push(value)
+}
+input = queue
+}
+
+output := intcode.NewWriterOutput(os.Stdout)
+output.Prefix = "Program outputs:  "
+
+if err := Run(context.Background(), input, output); err != nil {
+fmt.Println("program stopped:", err)
+os.Exit(1)
+}
+}
+`)
+    }
+
+    formatted, err := format.Source(src.Bytes())
+    if err != nil {
+        return nil, err
+    }
+
+    _, err = w.Write(formatted)
+    return codeWrites, err
+}
+
+// Writes the body of the switch case executing the instruction on the address
+func writeCase(w io.Writer, address int, i *Instruction) {
+    next := address + i.Length
+    operand := func(j int) string {
+        return nativeOperand(address, i.Params[j])
+    }
+    // Result is stored with the position already moved, the rest of the program is interpreted when it modifies code
+    store := func(value string) {
+        fmt.Fprintf(w, "pc = %d\nif r.Store(%d, %s, %s) {\nreturn r.Interpret(pc, rb)\n}\n",
+            next, address, nativeAddress(i.Params[len(i.Params) - 1]), value)
+    }
+
+    switch i.Operation {
+    case Add:
+        store(operand(0) + " + " + operand(1))
+    case Multiply:
+        store(operand(0) + " * " + operand(1))
+    case LessThan, Equals:
+        comparison := " < "
+        if i.Operation == Equals {
+            comparison = " == "
+        }
+        fmt.Fprintf(w, "flag := int64(0)\nif %s%s%s {\nflag = 1\n}\n", operand(0), comparison, operand(1))
+        store("flag")
+    case Read:
+        store(fmt.Sprintf("r.In(%d)", address))
+    case Write:
+        fmt.Fprintf(w, "r.Out(%d, %s)\npc = %d\n", address, operand(0), next)
+    case JumpIfTrue, JumpIfFalse:
+        comparison := " != "
+        if i.Operation == JumpIfFalse {
+            comparison = " == "
+        }
+        // Both parameters are read before the jump like in the interpreter
+        fmt.Fprintf(w, "value, target := %s, %s\nif value%s0 {\npc = int(target)\n} else {\npc = %d\n}\n",
+            operand(0), operand(1), comparison, next)
+    case SetRelativeBase:
+        fmt.Fprintf(w, "rb += int(%s)\npc = %d\n", operand(0), next)
+    case Terminate:
+        fmt.Fprint(w, "return nil\n")
+    }
+}
+
+// Go expression of the parameter value
+func nativeOperand(address int, param InstructionParam) string {
+    switch param.Mode {
+    case ImmediateMode:
+        return fmt.Sprint(param.Value)
+    case RelativeMode:
+        return fmt.Sprintf("r.Load(%d, int64(rb) + %d)", address, param.Value)
+    default:
+        return fmt.Sprintf("r.Load(%d, %d)", address, param.Value)
+    }
+}
+
+// Go expression of the address the parameter writes to
+func nativeAddress(param InstructionParam) string {
+    if param.Mode == RelativeMode {
+        return fmt.Sprintf("int64(rb) + %d", param.Value)
+    }
+    return fmt.Sprint(param.Value)
+}
+
+// Returns sorted addresses of the valid instructions reachable from the first address, indirect jumps are not followed
+func reachableInstructions(code []int64) []int {
+    visited := map[int]bool{}
+    pending := []int{0}
+
+    for len(pending) > 0 {
+        address := pending[len(pending) - 1]
+        pending = pending[:len(pending) - 1]
+        if visited[address] {
+            continue
+        }
+
+        instruction, ok := DecodeInstruction(code, address)
+        if !ok {
+            continue
+        }
+        visited[address] = true
+
+        switch instruction.Operation {
+        case Terminate:
+        case JumpIfTrue, JumpIfFalse:
+            if instruction.Params[1].Mode == ImmediateMode {
+                pending = append(pending, int(instruction.Params[1].Value))
+            }
+            pending = append(pending, address + instruction.Length)
+        default:
+            pending = append(pending, address + instruction.Length)
+        }
+    }
+
+    addresses := make([]int, 0, len(visited))
+    for address := range visited {
+        addresses = append(addresses, address)
+    }
+    sort.Ints(addresses)
+    return addresses
+}
+
+func joinValues(values []int64) string {
+    words := make([]string, len(values))
+    for k, value := range values {
+        words[k] = fmt.Sprint(value)
+    }
+    return strings.Join(words, ", ")
+}
@@ -0,0 +1,95 @@
+package intcode_test
+
+import (
+    "bytes"
+    "context"
+    "fmt"
+    "io/ioutil"
+    "reflect"
+    "testing"
+
+    "github.com/tomasbobek/AdventOfCode19/intcode"
+    "github.com/tomasbobek/AdventOfCode19/intcode/internal/native/day5"
+    "github.com/tomasbobek/AdventOfCode19/intcode/internal/native/day7"
+    "github.com/tomasbobek/AdventOfCode19/intcode/internal/native/day9"
+)
+
+// Day programs transpiled by go generate in internal/native, building them type-checks the generated source
+var transpiledDays = []struct {
+    day    int
+    run    func(ctx context.Context, input intcode.Input, output intcode.Output) error
+    inputs [][]int64
+}{
+    {5, day5.Run, [][]int64{{1}, {5}}},
+    {7, day7.Run, [][]int64{{4, 0}, {2, 17}}},
+    {9, day9.Run, [][]int64{{1}, {2}}},
+}
+
+// The generated source is the same as the one transpiled now, so the native packages test the current transpiler
+func TestTranspiledSourceIsCurrent(t *testing.T) {
+    for _, test := range transpiledDays {
+        t.Run(fmt.Sprint("day ", test.day), func(t *testing.T) {
+            code, err := intcode.ReadCodeFile(fmt.Sprintf("../%d/code", test.day))
+            if err != nil {
+                t.Fatal(err)
+            }
+
+            var source bytes.Buffer
+            options := intcode.TranspileOptions{Package: fmt.Sprint("day", test.day), Source: fmt.Sprintf("../../../%d/code", test.day)}
+            if _, err := intcode.Transpile(code, &source, options); err != nil {
+                t.Fatal(err)
+            }
+
+            generated, err := ioutil.ReadFile(fmt.Sprintf("internal/native/day%d/day%d.go", test.day, test.day))
+            if err != nil {
+                t.Fatal(err)
+            }
+            if !bytes.Equal(source.Bytes(), generated) {
+                t.Error("generated source is out of date, run go generate ./intcode/internal/native")
+            }
+        })
+    }
+}
+
+func TestTranspiledOutputs(t *testing.T) {
+    for _, test := range transpiledDays {
+        code, err := intcode.ReadCodeFile(fmt.Sprintf("../%d/code", test.day))
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        for _, inputs := range test.inputs {
+            t.Run(fmt.Sprint("day ", test.day, " ", inputs), func(t *testing.T) {
+                interpreted := &intcode.SliceOutput{}
+                program := &intcode.Program{Quiet: true, Input: intcode.NewQueueInput(inputs...), Output: interpreted}
+                program.LoadCode(code)
+                if err := program.Execute(); err != nil {
+                    t.Fatal(err)
+                }
+
+                native := &intcode.SliceOutput{}
+                if err := test.run(context.Background(), intcode.NewQueueInput(inputs...), native); err != nil {
+                    t.Fatal(err)
+                }
+
+                if !reflect.DeepEqual(native.Values, interpreted.Values) {
+                    t.Errorf("native outputs %v, interpreted %v", native.Values, interpreted.Values)
+                }
+            })
+        }
+    }
+}
+
+// Transpiled counterpart of BenchmarkExecuteDay9
+func BenchmarkExecuteDay9Transpiled(b *testing.B) {
+    b.ReportAllocs()
+    for n := 0; n < b.N; n++ {
+        output := &intcode.SliceOutput{}
+        if err := day9.Run(context.Background(), intcode.NewQueueInput(2), output); err != nil {
+            b.Fatal(err)
+        }
+        if len(output.Values) != 1 {
+            b.Fatal("unexpected output", output.Values)
+        }
+    }
+}
@@ -0,0 +1,181 @@
+package intcode
+
+import (
+    "fmt"
+    "io"
+    "strconv"
+    "strings"
+)
+
+// WatchAccess selects the memory accesses a watchpoint fires on
+type WatchAccess int
+
+const (
+    WatchRead WatchAccess = 1 << iota
+    WatchWrite
+    WatchReadWrite = WatchRead | WatchWrite
+)
+
+func (a WatchAccess) String() string {
+    switch a {
+    case WatchRead:
+        return "r"
+    case WatchWrite:
+        return "w"
+    case WatchReadWrite:
+        return "rw"
+    default:
+        return fmt.Sprintf("WatchAccess(%d)", int(a))
+    }
+}
+
+// Watchpoint fires on the accesses to the addresses from From to To, both inclusive
+type Watchpoint struct {
+    From   int64
+    To     int64
+    Access WatchAccess
+}
+
+func (w Watchpoint) String() string {
+    if w.From == w.To {
+        return fmt.Sprintf("%d:%s", w.From, w.Access)
+    }
+    return fmt.Sprintf("%d-%d:%s", w.From, w.To, w.Access)
+}
+
+func (w Watchpoint) contains(address int64) bool {
+    return address >= w.From && address <= w.To
+}
+
+// ParseWatchpoint reads watchpoint in the form from[-to][:r|w|rw], it fires on both reads and writes by default
+func ParseWatchpoint(s string) (Watchpoint, error) {
+    watchpoint := Watchpoint{Access: WatchReadWrite}
+
+    addresses := s
+    if colon := strings.IndexByte(s, ':'); colon >= 0 {
+        addresses = s[:colon]
+        switch s[colon+1:] {
+        case "r":
+            watchpoint.Access = WatchRead
+        case "w":
+            watchpoint.Access = WatchWrite
+        case "rw":
+        default:
+            return watchpoint, fmt.Errorf("unknown access %q in watchpoint %q, use r, w or rw", s[colon+1:], s)
+        }
+    }
+
+    from, to := addresses, addresses
+    if dash := strings.IndexByte(addresses, '-'); dash > 0 {
+        from, to = addresses[:dash], addresses[dash+1:]
+    }
+
+    var err error
+    if watchpoint.From, err = strconv.ParseInt(from, 10, 64); err != nil {
+        return watchpoint, fmt.Errorf("invalid watchpoint %q: %v", s, err)
+    }
+    if watchpoint.To, err = strconv.ParseInt(to, 10, 64); err != nil {
+        return watchpoint, fmt.Errorf("invalid watchpoint %q: %v", s, err)
+    }
+    if watchpoint.From < 0 || watchpoint.To < watchpoint.From {
+        return watchpoint, fmt.Errorf("invalid address range in watchpoint %q", s)
+    }
+
+    return watchpoint, nil
+}
+
+// MemoryAccess is a read or write of the memory by the instruction on Address, NewValue equals OldValue for reads
+type MemoryAccess struct {
+    // Number of the step the access happened in (see Program.Steps)
+    Step     int64
+    Address  int
+    Target   int64
+    Access   WatchAccess
+    OldValue int64
+    NewValue int64
+}
+
+func (a MemoryAccess) String() string {
+    if a.Access == WatchRead {
+        return fmt.Sprintf("step %d: instruction at %d reads %d = %d", a.Step, a.Address, a.Target, a.OldValue)
+    }
+    return fmt.Sprintf("step %d: instruction at %d writes %d: %d -> %d", a.Step, a.Address, a.Target, a.OldValue, a.NewValue)
+}
+
+// Watcher observes the memory accesses of a Program, it is filled while it is assigned to Program.Watcher
+type Watcher struct {
+    Watchpoints []Watchpoint
+    // Accesses that fired some of the watchpoints
+    Hits        []MemoryAccess
+    // When set, writes into the code loaded by LoadCode are recorded in CodeWrites
+    AuditCode   bool
+    CodeWrites  []MemoryAccess
+    // When set, hits and code writes are also written here as lines of text as they happen
+    Log         io.Writer
+}
+
+// Watch adds watchpoint on the address range
+func (w *Watcher) Watch(from int64, to int64, access WatchAccess) {
+    w.Watchpoints = append(w.Watchpoints, Watchpoint{From: from, To: to, Access: access})
+}
+
+// Unwatch removes the watchpoints with exactly given address range and reports whether there was any
+func (w *Watcher) Unwatch(from int64, to int64) bool {
+    kept := w.Watchpoints[:0]
+    for _, watchpoint := range w.Watchpoints {
+        if watchpoint.From != from || watchpoint.To != to {
+            kept = append(kept, watchpoint)
+        }
+    }
+
+    removed := len(kept) < len(w.Watchpoints)
+    w.Watchpoints = kept
+    return removed
+}
+
+// Removes the hits and code writes of the step and the later ones, they were undone by the Journal
+func (w *Watcher) undo(step int64) {
+    for len(w.Hits) > 0 && w.Hits[len(w.Hits) - 1].Step >= step {
+        w.Hits = w.Hits[:len(w.Hits) - 1]
+    }
+    for len(w.CodeWrites) > 0 && w.CodeWrites[len(w.CodeWrites) - 1].Step >= step {
+        w.CodeWrites = w.CodeWrites[:len(w.CodeWrites) - 1]
+    }
+}
+
+func (w *Watcher) isWatched(address int64, access WatchAccess) bool {
+    for _, watchpoint := range w.Watchpoints {
+        if watchpoint.Access & access != 0 && watchpoint.contains(address) {
+            return true
+        }
+    }
+    return false
+}
+
+func (w *Watcher) recordRead(p *Program, address int64, value int64) {
+    if w.isWatched(address, WatchRead) {
+        w.hit(MemoryAccess{Step: p.Steps, Address: p.Position, Target: address, Access: WatchRead, OldValue: value, NewValue: value})
+    }
+}
+
+func (w *Watcher) recordWrite(p *Program, address int64, oldValue int64, newValue int64) {
+    access := MemoryAccess{Step: p.Steps, Address: p.Position, Target: address, Access: WatchWrite, OldValue: oldValue, NewValue: newValue}
+
+    if w.isWatched(address, WatchWrite) {
+        w.hit(access)
+    }
+
+    if w.AuditCode && address < int64(p.codeLength) {
+        w.CodeWrites = append(w.CodeWrites, access)
+        if w.Log != nil {
+            fmt.Fprintln(w.Log, "code write:", access)
+        }
+    }
+}
+
+func (w *Watcher) hit(access MemoryAccess) {
+    w.Hits = append(w.Hits, access)
+    if w.Log != nil {
+        fmt.Fprintln(w.Log, "watchpoint:", access)
+    }
+}